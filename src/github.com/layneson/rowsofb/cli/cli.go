@@ -3,6 +3,7 @@ package cli
 import (
 	"bufio"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/color"
@@ -19,11 +20,21 @@ var resultColor = color.New(color.FgMagenta)
 
 var scan = bufio.NewScanner(os.Stdin)
 
+// environment is the environment the CLI evaluates in. Matrix entries may refer to its variables.
+var environment *env.E
+
 // Run starts the CLI loop.
 func Run() {
-	e := env.New(defineMatrix, defineAnonymousMatrix, defineScalar)
+	environment = env.New(defineMatrix, defineAnonymousMatrix, defineScalar)
+	e := environment
 
 	for {
+		if e.Modulus() != 0 {
+			promptColor.Printf("(mod %d) ", e.Modulus())
+		}
+		if e.FloatMode() {
+			promptColor.Print("(float) ")
+		}
 		promptColor.Print("> ")
 
 		cmdInputColor.Set()
@@ -31,6 +42,13 @@ func Run() {
 		scan.Scan()
 		line := scan.Text()
 
+		if ok, err := runCommand(e, line); ok {
+			if err != nil {
+				reportError("Command Error: ", err)
+			}
+			continue
+		}
+
 		toks, err := lang.Lex(line)
 		if err != nil {
 			reportError("Lexing Error: ", err)
@@ -49,31 +67,30 @@ func Run() {
 			continue
 		}
 
-		switch val.VType {
-		case env.MVar:
-			e.SetMVar('Z', val.MValue)
-			resultColor.Println(renderMatrix(val.MValue))
-		case env.SVar:
-			e.SetSVar('z', val.SValue)
-			resultColor.Println(val.SValue)
+		if val.VType.IsMatrix() {
+			e.SetVar('Z', val)
+		} else {
+			e.SetVar('z', val)
 		}
+
+		resultColor.Println(renderValue(val))
 	}
 }
 
 func reportError(prefix string, e error) {
-	errorColor.Printf("[!] %s%v.\n", prefix, e)
+	errorColor.Printf("[!] %s%s.\n", prefix, renderExponents(e.Error()))
 }
 
 func reportErrorMsg(message string) {
 	errorColor.Printf("[!] %s.\n", message)
 }
 
-func defineMatrix(v rune) (matrix.M, bool) {
+func defineMatrix(v rune) (*env.Value, bool) {
 	promptColor.Printf("Define matrix %c:\n", v)
 	return defineMatrixAgnostic()
 }
 
-func defineAnonymousMatrix() (matrix.M, bool) {
+func defineAnonymousMatrix() (*env.Value, bool) {
 	promptColor.Println("Define anonymous matrix:")
 	return defineMatrixAgnostic()
 }
@@ -94,31 +111,29 @@ func defineScalar(v rune) (matrix.Frac, bool) {
 	return frac, true
 }
 
-func defineMatrixAgnostic() (matrix.M, bool) {
+func defineMatrixAgnostic() (*env.Value, bool) {
 	matInputColor.Set()
 
 	scan.Scan()
 	first := strings.TrimSpace(scan.Text())
 
 	if first == "" {
-		return matrix.M{}, false
+		return nil, false
 	}
 
 	firstFields := strings.Split(first, "\t")
 
 	c := len(firstFields)
 
-	values := []matrix.Frac{}
+	values := []*env.Value{}
 
-	firstFracs, err := parseMatrixRow(firstFields)
+	firstEntries, err := parseMatrixRow(firstFields)
 	if err != nil {
 		reportError("Could not define matrix: ", err)
-		return matrix.M{}, false
+		return nil, false
 	}
 
-	for _, f := range firstFracs {
-		values = append(values, f)
-	}
+	values = append(values, firstEntries...)
 
 	for {
 		scan.Scan()
@@ -132,34 +147,63 @@ func defineMatrixAgnostic() (matrix.M, bool) {
 
 		if len(fields) != c {
 			reportErrorMsg("Matrix has uneven rows")
-			return matrix.M{}, false
+			return nil, false
 		}
 
-		fracs, err := parseMatrixRow(fields)
+		entries, err := parseMatrixRow(fields)
 		if err != nil {
 			reportError("Could not define matrix: ", err)
-			return matrix.M{}, false
+			return nil, false
 		}
 
-		for _, f := range fracs {
-			values = append(values, f)
-		}
+		values = append(values, entries...)
+	}
+
+	val, err := env.MatrixFromEntries(len(values)/c, c, values)
+	if err != nil {
+		reportError("Could not define matrix: ", err)
+		return nil, false
 	}
 
-	return matrix.NewWithValues(len(values)/c, c, values), true
+	return val, true
 }
 
-func parseMatrixRow(fields []string) ([]matrix.Frac, error) {
-	fracs := []matrix.Frac{}
+func parseMatrixRow(fields []string) ([]*env.Value, error) {
+	entries := []*env.Value{}
 
 	for _, f := range fields {
-		frac, err := matrix.ParseFrac(f)
+		entry, err := parseEntry(f)
 		if err != nil {
-			return fracs, err
+			return entries, err
 		}
 
-		fracs = append(fracs, frac)
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseEntry parses a single matrix entry. Decimal entries such as "0.25" are floating-point.
+// Entries which are not plain numbers, such as "2*k" or "x - 1", are evaluated as expressions in the current environment.
+func parseEntry(s string) (*env.Value, error) {
+	frac, err := matrix.ParseFrac(strings.TrimSpace(s))
+	if err == nil {
+		return &env.Value{VType: env.SVar, SValue: frac}, nil
+	}
+
+	if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+		return &env.Value{VType: env.FVar, FValue: f}, nil
+	}
+
+	toks, err := lang.Lex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	enode, err := lang.Parse(toks)
+	if err != nil {
+		return nil, err
 	}
 
-	return fracs, nil
+	return env.Evaluate(enode, environment)
 }
@@ -0,0 +1,688 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/layneson/rowsofb/env"
+	"github.com/layneson/rowsofb/lang"
+	"github.com/layneson/rowsofb/matrix"
+)
+
+// A command is a line of input which configures the environment instead of being evaluated as an expression.
+// Commands are recognized by their first word.
+type command struct {
+	usage   string
+	handler func(*env.E, []string) error
+}
+
+var commands = map[string]command{
+	"mod": command{
+		"mod <prime> | mod off",
+		func(e *env.E, args []string) error {
+			if len(args) == 0 {
+				if e.Modulus() == 0 {
+					resultColor.Println("Evaluating over the rationals")
+				} else {
+					resultColor.Printf("Evaluating modulo %d\n", e.Modulus())
+				}
+
+				return nil
+			}
+
+			if len(args) != 1 {
+				return errUsage
+			}
+
+			if args[0] == "off" {
+				return e.SetModulus(0)
+			}
+
+			p, err := strconv.Atoi(args[0])
+			if err != nil || p == 0 {
+				return errUsage
+			}
+
+			return e.SetModulus(p)
+		},
+	},
+
+	"float": command{
+		"float [digits [tolerance]] | float off",
+		func(e *env.E, args []string) error {
+			if len(args) == 1 && args[0] == "off" {
+				e.SetExactMode()
+				return nil
+			}
+
+			if len(args) > 2 {
+				return errUsage
+			}
+
+			precision, tolerance := e.Precision(), e.Tolerance()
+
+			if len(args) >= 1 {
+				p, err := strconv.Atoi(args[0])
+				if err != nil {
+					return errUsage
+				}
+
+				precision = p
+			}
+
+			if len(args) == 2 {
+				t, err := strconv.ParseFloat(args[1], 64)
+				if err != nil {
+					return errUsage
+				}
+
+				tolerance = t
+			}
+
+			err := e.SetFloatMode(precision, tolerance)
+			if err != nil {
+				return err
+			}
+
+			resultColor.Printf("Evaluating approximately with %d significant digits and tolerance %g\n", precision, tolerance)
+
+			return nil
+		},
+	},
+
+	"form": command{
+		"form <matrix variable> <quadratic form>",
+		func(e *env.E, args []string) error {
+			if len(args) < 2 || len(args[0]) != 1 || env.GetVarType(rune(args[0][0])) != env.MVar {
+				return errUsage
+			}
+
+			m, vars, err := matrix.ParseQuadraticForm(strings.Join(args[1:], " "))
+			if err != nil {
+				return err
+			}
+
+			e.SetMVar(rune(args[0][0]), m)
+
+			names := []string{}
+			for _, v := range vars {
+				names = append(names, string(v))
+			}
+
+			resultColor.Printf("Variables: %s\n", strings.Join(names, ", "))
+			resultColor.Println(renderMatrix(m))
+
+			return nil
+		},
+	},
+
+	"balance": command{
+		"balance <reactants> -> <products>",
+		func(e *env.E, args []string) error {
+			if len(args) == 0 {
+				return errUsage
+			}
+
+			rx, err := matrix.ParseReaction(strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+
+			resultColor.Printf("Element counts (rows %s; product columns negated):\n", strings.Join(rx.Elements, ", "))
+			resultColor.Println(renderMatrix(rx.Counts))
+
+			coeffs, err := rx.Balance()
+			if err != nil {
+				return err
+			}
+
+			side := func(species []string, coeffs []int) string {
+				terms := []string{}
+				for i, s := range species {
+					if coeffs[i] != 1 {
+						s = strconv.Itoa(coeffs[i]) + s
+					}
+					terms = append(terms, s)
+				}
+
+				return strings.Join(terms, " + ")
+			}
+
+			n := len(rx.Reactants)
+			resultColor.Printf("%s -> %s\n", side(rx.Reactants, coeffs[:n]), side(rx.Products, coeffs[n:]))
+
+			return nil
+		},
+	},
+
+	"graph": command{
+		"graph [matrix variable] <edge list, such as 1-2, 2-3 or 1->2, 2->3>",
+		func(e *env.E, args []string) error {
+			v := 'Z'
+			if len(args) > 0 && len(args[0]) == 1 && env.GetVarType(rune(args[0][0])) == env.MVar {
+				v, args = rune(args[0][0]), args[1:]
+			}
+
+			if len(args) == 0 {
+				return errUsage
+			}
+
+			adj, err := matrix.ParseEdgeList(strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+
+			e.SetMVar(v, adj)
+
+			resultColor.Printf("Adjacency matrix of %d vertices stored in %c:\n", adj.Rows(), v)
+			resultColor.Println(renderMatrix(adj))
+
+			return nil
+		},
+	},
+
+	"bits": command{
+		"bits <matrix variable> [bit string, such as 1011]",
+		func(e *env.E, args []string) error {
+			if len(args) == 0 || len(args[0]) != 1 || env.GetVarType(rune(args[0][0])) != env.MVar {
+				return errUsage
+			}
+
+			v := rune(args[0][0])
+
+			// With no bits given, the vector already in the variable is printed as bits.
+			if len(args) == 1 {
+				if e.GetVar(v).VType != env.MVar {
+					return fmt.Errorf("%c does not hold a matrix of numbers", v)
+				}
+
+				m := e.GetMVar(v)
+				if m.Rows() != 1 && m.Cols() != 1 {
+					return fmt.Errorf("%c holds a %dx%d matrix, not a vector", v, m.Rows(), m.Cols())
+				}
+
+				resultColor.Println(matrix.FormatBits(m))
+
+				return nil
+			}
+
+			m, err := matrix.ParseBits(strings.Join(args[1:], ""))
+			if err != nil {
+				return err
+			}
+
+			e.SetMVar(v, m)
+
+			resultColor.Printf("Vector of %d bits stored in %c:\n", m.Rows(), v)
+			resultColor.Println(renderMatrix(m))
+
+			return nil
+		},
+	},
+
+	"geom": command{
+		"geom line P Q | line P dir D | plane P Q R | plane P normal N | intersect <object> and <object> | distance P to <object> | distance <line> and <line> | project P onto <object>",
+		func(e *env.E, args []string) error {
+			if len(args) == 0 {
+				return errUsage
+			}
+
+			switch args[0] {
+			case "line", "plane":
+				obj, rest, err := parseGeomObject(e, args)
+				if err != nil {
+					return err
+				}
+
+				if len(rest) > 0 {
+					return errUsage
+				}
+
+				resultColor.Println(obj)
+			case "intersect":
+				a, rest, err := parseGeomObject(e, args[1:])
+				if err != nil {
+					return err
+				}
+
+				if len(rest) == 0 || rest[0] != "and" {
+					return errUsage
+				}
+
+				b, rest, err := parseGeomObject(e, rest[1:])
+				if err != nil {
+					return err
+				}
+
+				if len(rest) > 0 {
+					return errUsage
+				}
+
+				// A line and a plane are intersected in that order.
+				if a.plane != nil && b.line != nil {
+					a, b = b, a
+				}
+
+				var in matrix.Intersection
+				switch {
+				case a.line != nil && b.line != nil:
+					in, err = matrix.IntersectLines(*a.line, *b.line)
+				case a.line != nil:
+					in, err = matrix.IntersectLinePlane(*a.line, *b.plane)
+				default:
+					in = matrix.IntersectPlanes(*a.plane, *b.plane)
+				}
+
+				if err != nil {
+					return err
+				}
+
+				switch {
+				case in.Point != nil:
+					resultColor.Printf("They meet at the point %s\n", matrix.VectorString(in.Point))
+				case in.Relation == matrix.Intersecting:
+					resultColor.Printf("They meet in the line %v\n", in.Line)
+				case in.Relation == matrix.Coincident && b.plane != nil && a.line != nil:
+					resultColor.Printf("The line lies in the plane: %v\n", in.Line)
+				case in.Relation == matrix.Coincident:
+					resultColor.Printf("They are the same %s: %v\n", args[1], a)
+				default:
+					resultColor.Printf("They are %v and do not meet\n", in.Relation)
+				}
+			case "distance":
+				if len(args) > 2 && args[2] == "to" {
+					p, err := vectorVariable(e, args[1])
+					if err != nil {
+						return err
+					}
+
+					obj, rest, err := parseGeomObject(e, args[3:])
+					if err != nil {
+						return err
+					}
+
+					if len(rest) > 0 {
+						return errUsage
+					}
+
+					var d matrix.Radical
+					if obj.line != nil {
+						d, err = matrix.DistanceToLine(p, *obj.line)
+					} else {
+						d, err = matrix.DistanceToPlane(p, *obj.plane)
+					}
+
+					if err != nil {
+						return err
+					}
+
+					resultColor.Println(d)
+
+					return nil
+				}
+
+				a, rest, err := parseGeomObject(e, args[1:])
+				if err != nil {
+					return err
+				}
+
+				if len(rest) == 0 || rest[0] != "and" {
+					return errUsage
+				}
+
+				b, rest, err := parseGeomObject(e, rest[1:])
+				if err != nil {
+					return err
+				}
+
+				if len(rest) > 0 || a.line == nil || b.line == nil {
+					return errUsage
+				}
+
+				d, err := matrix.DistanceBetweenLines(*a.line, *b.line)
+				if err != nil {
+					return err
+				}
+
+				resultColor.Println(d)
+			case "project":
+				if len(args) < 3 || args[2] != "onto" {
+					return errUsage
+				}
+
+				p, err := vectorVariable(e, args[1])
+				if err != nil {
+					return err
+				}
+
+				obj, rest, err := parseGeomObject(e, args[3:])
+				if err != nil {
+					return err
+				}
+
+				if len(rest) > 0 {
+					return errUsage
+				}
+
+				var q []matrix.Frac
+				if obj.line != nil {
+					q, err = matrix.ProjectOntoLine(p, *obj.line)
+				} else {
+					q, err = matrix.ProjectOntoPlane(p, *obj.plane)
+				}
+
+				if err != nil {
+					return err
+				}
+
+				resultColor.Println(matrix.VectorString(q))
+			default:
+				return errUsage
+			}
+
+			return nil
+		},
+	},
+
+	"hill": command{
+		"hill encrypt|decrypt <key matrix variable> \"text\"",
+		func(e *env.E, args []string) error {
+			toks, err := lang.Lex(args[0])
+			if err != nil {
+				return err
+			}
+
+			if len(toks) != 4 || toks[0].TType != lang.TTFunc || toks[0].Literal != "encrypt" && toks[0].Literal != "decrypt" ||
+				toks[2].TType != lang.TTString || toks[3].TType != lang.TTEOF {
+				return errUsage
+			}
+
+			key := toks[1].Literal
+			if toks[1].TType != lang.TTMVar || e.GetVar(rune(key[0])).VType != env.MVar {
+				return fmt.Errorf("%q is not a matrix variable holding a matrix of numbers", key)
+			}
+
+			text := toks[2].Literal
+
+			k := e.GetMVar(rune(key[0]))
+
+			if toks[0].Literal == "encrypt" {
+				c, err := matrix.HillEncrypt(k, text)
+				if err != nil {
+					return err
+				}
+
+				resultColor.Println(c)
+
+				return nil
+			}
+
+			p, inv, err := matrix.HillDecrypt(k, text)
+			if err != nil {
+				return err
+			}
+
+			resultColor.Println("Inverse key modulo 26:")
+			resultColor.Println(renderMatrix(inv))
+			resultColor.Println(p)
+
+			return nil
+		},
+	},
+
+	"lp": command{
+		"lp maximize|minimize C subject to A x <= B[, x >= 0] [steps], where C, A and B are uppercase matrix variables and C and B hold vectors",
+		func(e *env.E, args []string) error {
+			steps := len(args) > 0 && args[len(args)-1] == "steps"
+			if steps {
+				args = args[:len(args)-1]
+			}
+
+			// The nonnegativity constraint is implied, but may be written out.
+			if len(args) == 11 && strings.Join(args[8:], " ") == "x >= 0" {
+				args = args[:8]
+			}
+
+			if len(args) != 8 || args[0] != "maximize" && args[0] != "minimize" || args[2] != "subject" || args[3] != "to" || args[5] != "x" || args[6] != "<=" {
+				return errUsage
+			}
+
+			if e.Modulus() != 0 {
+				return fmt.Errorf("linear programs cannot be solved modulo a prime")
+			}
+
+			mats := []matrix.M{}
+			for _, arg := range []string{args[1], args[4], strings.TrimSuffix(args[7], ",")} {
+				if len(arg) == 1 && env.GetVarType(rune(arg[0])) == env.SVar {
+					return fmt.Errorf("%q is a scalar variable, which cannot hold a vector; store the vector in an uppercase matrix variable instead", arg)
+				}
+
+				if len(arg) != 1 || env.GetVarType(rune(arg[0])) != env.MVar || e.GetVar(rune(arg[0])).VType != env.MVar {
+					return fmt.Errorf("%q is not a matrix variable holding a matrix of numbers", arg)
+				}
+
+				mats = append(mats, e.GetMVar(rune(arg[0])))
+			}
+
+			c, err := matrix.VectorEntries(mats[0])
+			if err != nil {
+				return err
+			}
+
+			b, err := matrix.VectorEntries(mats[2])
+			if err != nil {
+				return err
+			}
+
+			minimize := args[0] == "minimize"
+			if minimize {
+				for i := range c {
+					c[i] = c[i].Neg()
+				}
+			}
+
+			sol, err := matrix.Simplex(c, mats[1], b)
+			if err != nil {
+				return err
+			}
+
+			if steps {
+				resultColor.Printf("Columns: %s | b\n", strings.Join(sol.Vars, " "))
+				if minimize {
+					resultColor.Println("The objective is negated and maximized")
+				}
+
+				for _, step := range sol.Steps {
+					if step.Entering == -1 {
+						resultColor.Printf("Phase %d, initial tableau:\n", step.Phase)
+					} else {
+						resultColor.Printf("Phase %d, %s enters and %s leaves:\n", step.Phase, sol.Vars[step.Entering], sol.Vars[step.Leaving])
+					}
+
+					resultColor.Println(renderMatrix(step.Tableau))
+				}
+			}
+
+			switch sol.Status {
+			case matrix.Infeasible:
+				resultColor.Println("The problem is infeasible: no point satisfies the constraints")
+			case matrix.Unbounded:
+				resultColor.Println("The problem is unbounded: the objective has no optimum")
+			case matrix.Optimal:
+				if minimize {
+					resultColor.Printf("Minimum %v at x =\n", sol.Value.Neg())
+				} else {
+					resultColor.Printf("Maximum %v at x =\n", sol.Value)
+				}
+
+				resultColor.Println(renderMatrix(sol.X))
+			}
+
+			return nil
+		},
+	},
+
+	"seed": command{
+		"seed [number]",
+		func(e *env.E, args []string) error {
+			if len(args) > 1 {
+				return errUsage
+			}
+
+			if len(args) == 1 {
+				seed, err := strconv.ParseInt(args[0], 10, 64)
+				if err != nil {
+					return errUsage
+				}
+
+				e.SetSeed(seed)
+			}
+
+			resultColor.Printf("Random matrices are drawn with seed %d\n", e.Seed())
+
+			return nil
+		},
+	},
+
+	"param": command{
+		"param <scalar variable>...",
+		func(e *env.E, args []string) error {
+			if len(args) == 0 {
+				return errUsage
+			}
+
+			for _, arg := range args {
+				if len(arg) != 1 || env.GetVarType(rune(arg[0])) != env.SVar {
+					return fmt.Errorf("%q is not a scalar variable", arg)
+				}
+			}
+
+			for _, arg := range args {
+				e.SetParam(rune(arg[0]))
+			}
+
+			return nil
+		},
+	},
+}
+
+var errUsage = fmt.Errorf("invalid arguments")
+
+// rawCommands are the commands whose handler receives the rest of the line as a single argument,
+// so that quoted text keeps its spacing.
+var rawCommands = map[string]bool{
+	"hill": true,
+}
+
+// runCommand runs the line as a command if its first word names one.
+// The bool return value is false if the line is not a command and should be evaluated as an expression.
+func runCommand(e *env.E, line string) (bool, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false, nil
+	}
+
+	cmd, ok := commands[fields[0]]
+	if !ok {
+		return false, nil
+	}
+
+	args := fields[1:]
+	if rawCommands[fields[0]] {
+		args = []string{strings.TrimSpace(strings.TrimSpace(line)[len(fields[0]):])}
+	}
+
+	err := cmd.handler(e, args)
+	if err == errUsage {
+		return true, fmt.Errorf("usage: %s", cmd.usage)
+	}
+
+	return true, err
+}
+
+// A geomObject is a line or a plane given to the geom command. Exactly one of its fields is set.
+type geomObject struct {
+	line  *matrix.Line
+	plane *matrix.Plane
+}
+
+func (obj geomObject) String() string {
+	if obj.line != nil {
+		return obj.line.String()
+	}
+
+	return obj.plane.String()
+}
+
+// parseGeomObject parses a line or plane from the start of args, written as "line P Q", "line P dir D",
+// "plane P Q R" or "plane P normal N", where each letter is a matrix variable holding a vector.
+// It returns the object and the arguments after it.
+func parseGeomObject(e *env.E, args []string) (geomObject, []string, error) {
+	if len(args) < 3 || args[0] != "line" && args[0] != "plane" {
+		return geomObject{}, nil, errUsage
+	}
+
+	// The vector after "dir" or "normal" is a direction rather than a point.
+	direction := args[2] == "dir" && args[0] == "line" || args[2] == "normal" && args[0] == "plane"
+
+	n := 3
+	if args[0] == "line" && !direction {
+		n = 2
+	}
+
+	if len(args) < n+1 {
+		return geomObject{}, nil, errUsage
+	}
+
+	vecs := [][]matrix.Frac{}
+	for i, arg := range args[1 : n+1] {
+		if direction && i == 1 {
+			continue
+		}
+
+		v, err := vectorVariable(e, arg)
+		if err != nil {
+			return geomObject{}, nil, err
+		}
+
+		vecs = append(vecs, v)
+	}
+
+	var obj geomObject
+	var err error
+
+	switch {
+	case args[0] == "line":
+		var l matrix.Line
+		if direction {
+			l, err = matrix.NewLine(vecs[0], vecs[1])
+		} else {
+			l, err = matrix.LineThrough(vecs[0], vecs[1])
+		}
+		obj.line = &l
+	default:
+		var pl matrix.Plane
+		if direction {
+			pl, err = matrix.NewPlane(vecs[0], vecs[1])
+		} else {
+			pl, err = matrix.PlaneThrough(vecs[0], vecs[1], vecs[2])
+		}
+		obj.plane = &pl
+	}
+
+	if err != nil {
+		return geomObject{}, nil, err
+	}
+
+	return obj, args[n+1:], nil
+}
+
+// vectorVariable returns the entries of the vector held in the named matrix variable.
+func vectorVariable(e *env.E, arg string) ([]matrix.Frac, error) {
+	if len(arg) != 1 || env.GetVarType(rune(arg[0])) != env.MVar || e.GetVar(rune(arg[0])).VType != env.MVar {
+		return nil, fmt.Errorf("%q is not a matrix variable holding a vector of numbers", arg)
+	}
+
+	return matrix.VectorEntries(e.GetMVar(rune(arg[0])))
+}
@@ -2,29 +2,275 @@ package cli
 
 import (
 	"bytes"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/layneson/rowsofb/env"
 	"github.com/layneson/rowsofb/matrix"
 )
 
+//renderValue renders a value of any type, followed by its notes.
+func renderValue(val *env.Value) string {
+	var s string
+
+	switch val.VType {
+	case env.MVar:
+		s = renderMatrix(val.MValue)
+	case env.SVar:
+		s = val.SValue.String()
+	case env.PMVar:
+		s = renderPolyMatrix(val.PMValue)
+	case env.PVar:
+		s = renderPoly(val.PValue)
+	case env.RMVar:
+		s = renderEntries(val.RMValue.Rows(), val.RMValue.Cols(), func(r, c int) string {
+			return renderExponents(val.RMValue.Get(r, c).String())
+		})
+	case env.RVar:
+		s = renderExponents(val.RValue.String())
+	case env.FMVar:
+		s = renderEntries(val.FMValue.Rows(), val.FMValue.Cols(), func(r, c int) string {
+			return renderFloat(val.FMValue.Get(r, c))
+		}) + "\n(approximate)"
+	case env.FVar:
+		s = "≈ " + renderFloat(val.FValue)
+	case env.RadMVar:
+		s = renderEntries(val.RadMValue.Rows(), val.RadMValue.Cols(), func(r, c int) string {
+			return val.RadMValue.Get(r, c).String()
+		})
+	case env.ExpMVar:
+		s = renderEntries(val.ExpMValue.Rows(), 1, func(r, c int) string {
+			return renderExpEntry(val.ExpMValue, r)
+		})
+	}
+
+	for _, note := range val.Notes {
+		s += "\n" + renderExponents(note.Label)
+		if note.Value != nil {
+			s += "\n" + renderValue(note.Value)
+		}
+	}
+
+	return s
+}
+
 func renderMatrix(m matrix.M) string {
-	smat := make([]string, m.Rows()*m.Cols())
-	cwidths := make([]int, m.Cols())
+	return renderEntries(m.Rows(), m.Cols(), func(r, c int) string {
+		return m.Get(r, c).String()
+	})
+}
+
+func renderPolyMatrix(m matrix.PM) string {
+	return renderEntries(m.Rows(), m.Cols(), func(r, c int) string {
+		return renderPoly(m.Get(r, c))
+	})
+}
+
+//renderExpEntry renders entry r of the vector as a sum of polynomials in t times exponentials, such as "(t + 1)e²ᵗ - 3e⁻ᵗ",
+//followed by the terms of its pairs of eigenvalues which are not rational.
+func renderExpEntry(v matrix.ExpVector, r int) string {
+	terms := []string{}
+
+	rates, polys := v.Entry(r)
+	for i, rate := range rates {
+		terms = append(terms, renderExpTerm(rate, polys[i]))
+	}
+
+	for _, pair := range v.Pairs {
+		terms = append(terms, renderPairTerm(pair, r)...)
+	}
+
+	return joinTerms(terms)
+}
+
+//joinTerms joins terms into a sum, turning the leading minus sign of a term into a subtraction.
+func joinTerms(terms []string) string {
+	if len(terms) == 0 {
+		return "0"
+	}
+
+	s := terms[0]
+	for _, term := range terms[1:] {
+		if strings.HasPrefix(term, "-") {
+			s += " - " + term[1:]
+		} else {
+			s += " + " + term
+		}
+	}
+
+	return s
+}
+
+//renderExpTerm renders a polynomial in t times e^{rate·t}, bracketing the polynomial if it has more than one term.
+func renderExpTerm(rate matrix.Frac, p matrix.Poly) string {
+	poly := renderExponents(p.StringIn('t'))
+
+	exp := renderExp(rate)
+	if exp == "" {
+		return poly
+	}
+
+	terms := 0
+	for j := 0; j <= p.Degree(); j++ {
+		if !p.Coeff(j).IsZero() {
+			terms++
+		}
+	}
+
+	switch {
+	case terms > 1:
+		return "(" + poly + ")" + exp
+	case poly == "1":
+		return exp
+	case poly == "-1":
+		return "-" + exp
+	case p.IsConstant() && !p.Coeff(0).IsWhole():
+		if p.Coeff(0).Numerator() < 0 {
+			return "-(" + p.Coeff(0).Neg().String() + ")" + exp
+		}
+
+		return "(" + poly + ")" + exp
+	}
+
+	return poly + exp
+}
+
+//renderExp renders e^{rate·t}, with a whole rate as a superscript such as "e⁻²ᵗ". It is empty if the rate is zero.
+func renderExp(rate matrix.Frac) string {
+	if rate.IsZero() {
+		return ""
+	}
+
+	if !rate.IsWhole() {
+		return "e^((" + rate.String() + ")t)"
+	}
+
+	n := rate.Reduce().Integer()
+
+	exp := "e"
+	if n < 0 {
+		exp += "⁻"
+		n = -n
+	}
+	if n != 1 {
+		exp += superscripts.Replace(strconv.Itoa(n))
+	}
+
+	return exp + "ᵗ"
+}
+
+//renderPairTerm renders entry r of a pair term e^{ut}(C(t)·Even + S(t)·Odd). For real eigenvalues u ± s, C = (e^{st} + e^{-st})/2
+//and S = (e^{st} - e^{-st})/2s, giving the two exponentials e^{(u ± s)t}. For complex eigenvalues u ± βi, C = cos(βt) and
+//S = sin(βt)/β. It returns the terms of the sum, which is empty if the entry is zero.
+func renderPairTerm(pair matrix.PairTerm, r int) []string {
+	even, odd := pair.Even.Get(r, 1), pair.Odd.Get(r, 1)
+	if even.IsZero() && odd.IsZero() {
+		return nil
+	}
+
+	root := pair.Root()
+
+	if pair.Delta.Numerator() > 0 {
+		//1/2s = s/2s².
+		half, k := even.Mul(matrix.NewFrac(1, 2)).Reduce(), root.Scale(odd.Div(pair.Delta.Mul(matrix.NewScalarFrac(2))))
+
+		terms := []string{}
+		for _, sign := range []matrix.Frac{matrix.NewScalarFrac(1), matrix.NewScalarFrac(-1)} {
+			exp := "e^((" + matrix.SurdString(pair.Rate, root.Scale(sign)) + ")t)"
+
+			switch {
+			case half.IsZero():
+				terms = append(terms, renderScaled(k.Scale(sign), exp))
+			case k.Coeff().IsZero():
+				terms = append(terms, renderScaled(matrix.NewRadical(half, 1), exp))
+			default:
+				terms = append(terms, "("+matrix.SurdString(half, k.Scale(sign))+")"+exp)
+			}
+		}
+
+		return terms
+	}
+
+	angle := "t"
+	switch {
+	case root.Coeff().Equals(matrix.NewScalarFrac(1)) && root.IsRational():
+	case root.Coeff().IsWhole() && root.IsRational():
+		angle = root.String() + "t"
+	default:
+		angle = "(" + root.String() + ")t"
+	}
+
+	//1/β = β/β².
+	terms := []string{}
+	if !even.IsZero() {
+		terms = append(terms, renderScaled(matrix.NewRadical(even, 1), "cos("+angle+")"))
+	}
+	if !odd.IsZero() {
+		terms = append(terms, renderScaled(root.Scale(odd.Div(pair.Delta.Neg())), "sin("+angle+")"))
+	}
+
+	exp := renderExp(pair.Rate)
+	if exp == "" {
+		return terms
+	}
+
+	if len(terms) > 1 {
+		return []string{"(" + joinTerms(terms) + ")" + exp}
+	}
+
+	if strings.HasPrefix(terms[0], "-") {
+		return []string{"-" + terms[0][1:] + exp}
+	}
+
+	return []string{terms[0] + exp}
+}
+
+//renderScaled renders the function f multiplied by c, leaving out a factor of one and bracketing fractions.
+func renderScaled(c matrix.Radical, f string) string {
+	switch {
+	case c.Coeff().Numerator() < 0:
+		return "-" + renderScaled(c.Scale(matrix.NewScalarFrac(-1)), f)
+	case !c.IsRational():
+		return c.String() + "·" + f
+	case c.String() == "1":
+		return f
+	case c.Coeff().IsWhole():
+		return c.String() + f
+	}
+
+	return "(" + c.String() + ")" + f
+}
+
+//renderFloat renders a floating-point value with the environment's display precision.
+func renderFloat(f float64) string {
+	if f == 0 {
+		f = 0 // avoid printing negative zero
+	}
+
+	return strconv.FormatFloat(f, 'g', environment.Precision(), 64)
+}
+
+//renderEntries renders a bracketed grid of rows x cols entries, where entry returns the string for a given row and column.
+func renderEntries(rows, cols int, entry func(r, c int) string) string {
+	smat := make([]string, rows*cols)
+	cwidths := make([]int, cols)
 	csum := 0
 
-	for c := 1; c <= m.Cols(); c++ {
+	for c := 1; c <= cols; c++ {
 		mwidth := 0
-		for r := 1; r <= m.Rows(); r++ {
-			str := m.Get(r, c).String()
-			smat[(r-1)*m.Cols()+(c-1)] = str
-			if len(str) > mwidth {
-				mwidth = len(str)
+		for r := 1; r <= rows; r++ {
+			str := entry(r, c)
+			smat[(r-1)*cols+(c-1)] = str
+			if utf8.RuneCountInString(str) > mwidth {
+				mwidth = utf8.RuneCountInString(str)
 			}
 		}
 		cwidths[c-1] = mwidth
 		csum += mwidth
 	}
 
-	nspace := csum + 4*(m.Cols()-1)
+	nspace := csum + 4*(cols-1)
 
 	var buff bytes.Buffer
 
@@ -34,16 +280,16 @@ func renderMatrix(m matrix.M) string {
 	}
 	buff.WriteString(" ┐\n")
 
-	for r := 1; r <= m.Rows(); r++ {
+	for r := 1; r <= rows; r++ {
 		buff.WriteString("│ ")
 
-		for c := 1; c <= m.Cols()-1; c++ {
-			str := padRight(smat[(r-1)*m.Cols()+(c-1)], cwidths[c-1])
+		for c := 1; c <= cols-1; c++ {
+			str := padRight(smat[(r-1)*cols+(c-1)], cwidths[c-1])
 			buff.WriteString(str)
 			buff.WriteString("    ") // 4 spaces
 		}
 
-		buff.WriteString(padRight(smat[(r-1)*m.Cols()+(m.Cols()-1)], cwidths[m.Cols()-1]))
+		buff.WriteString(padRight(smat[(r-1)*cols+(cols-1)], cwidths[cols-1]))
 
 		buff.WriteString(" │\n")
 	}
@@ -57,9 +303,43 @@ func renderMatrix(m matrix.M) string {
 	return buff.String()
 }
 
+var superscripts = strings.NewReplacer(
+	"0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴",
+	"5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹",
+)
+
+//renderPoly renders a polynomial with its exponents as superscripts, such as "x² - 3x + 1/2".
+func renderPoly(p matrix.Poly) string {
+	return renderExponents(p.String())
+}
+
+//renderExponents replaces each exponent written as "^2" or "^n" with superscript characters.
+//A caret followed by anything else is left alone.
+func renderExponents(s string) string {
+	terms := strings.Split(s, "^")
+
+	for i := 1; i < len(terms); i++ {
+		digits := 0
+		for digits < len(terms[i]) && terms[i][digits] >= '0' && terms[i][digits] <= '9' {
+			digits++
+		}
+
+		switch {
+		case digits > 0:
+			terms[i] = superscripts.Replace(terms[i][:digits]) + terms[i][digits:]
+		case strings.HasPrefix(terms[i], "n"):
+			terms[i] = "ⁿ" + terms[i][1:]
+		default:
+			terms[i] = "^" + terms[i]
+		}
+	}
+
+	return strings.Join(terms, "")
+}
+
 //padRight pads the string s with spaces on the right until s has length l.
 func padRight(s string, l int) string {
-	for len(s) < l {
+	for utf8.RuneCountInString(s) < l {
 		s = s + " "
 	}
 
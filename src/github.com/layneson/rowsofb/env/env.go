@@ -2,29 +2,45 @@ package env
 
 import (
 	"fmt"
+	"math/rand"
 	"strconv"
+	"time"
 
 	"github.com/layneson/rowsofb/lang"
 	"github.com/layneson/rowsofb/matrix"
 )
 
-// MatrixDefiner is a function which takes a matrix variable name and returns the matrix the user defined for it.
+// MatrixDefiner is a function which takes a matrix variable name and returns the matrix value the user defined for it.
 // The bool return value is false if the user cancelled the process and true otherwise.
-type MatrixDefiner func(rune) (matrix.M, bool)
+type MatrixDefiner func(rune) (*Value, bool)
 
-// AnonymousMatrixDefiner is a function which returns a user-defined anonymous matrix.
+// AnonymousMatrixDefiner is a function which returns a user-defined anonymous matrix value.
 // The bool return value is false if the user cancelled the process and true otherwise.
-type AnonymousMatrixDefiner func() (matrix.M, bool)
+type AnonymousMatrixDefiner func() (*Value, bool)
 
 // ScalarDefiner is a function which takes a scalar variable name and returns the scalar the user defined for it.
 // The bool return value is false if the user cancelled the process and true otherwise.
 type ScalarDefiner func(rune) (matrix.Frac, bool)
 
-// E represents an environment which contains 26 matrix variables (A-Z) and 26 scalar variables (a-z).
+// E represents an environment which contains 26 matrix variables (A-Z) and 25 scalar variables (a-z, except for
+// the indeterminate x). Matrix variables may hold polynomial matrices and scalar variables may hold polynomials.
+// A scalar variable may also be unbound, in which case it is a symbolic parameter.
 // The variables Z and z are set to the results of matrix and scalar-resolving expressions, respectively.
+// If a modulus is set, every expression is evaluated over the integers modulo that prime.
+// In floating-point mode, numbers and variables are approximated with float64 values instead.
+// Random matrices are drawn from a seeded source, so a session can be replayed by setting the same seed.
 type E struct {
-	mvars []matrix.M
-	svars []matrix.Frac
+	mvars []*Value
+	svars []*Value
+
+	modulus int // zero if expressions are evaluated over the rationals
+
+	float     bool
+	precision int     // significant digits shown for floating-point results
+	tolerance float64 // magnitude below which floating-point values are treated as zero
+
+	seed int64      // seed of rng, kept so that random results can be reproduced
+	rng  *rand.Rand // source of randomness for the random matrix functions
 
 	mdef  MatrixDefiner
 	amdef AnonymousMatrixDefiner
@@ -34,41 +50,86 @@ type E struct {
 // New creates a new environment. Each matrix variable defaults to a 3x3 zero matrix
 // and each scalar variable defaults to zero.
 func New(mdef MatrixDefiner, amdef AnonymousMatrixDefiner, sdef ScalarDefiner) *E {
-	e := &E{mdef: mdef, amdef: amdef, sdef: sdef}
+	e := &E{mdef: mdef, amdef: amdef, sdef: sdef, precision: 6, tolerance: 1e-10}
+	e.SetSeed(time.Now().UnixNano())
 
 	for r := 'A'; r <= 'Z'; r++ {
-		e.mvars = append(e.mvars, matrix.New(3, 3))
+		e.mvars = append(e.mvars, valueFromMatrix(matrix.New(3, 3)))
 	}
 
 	for r := 'a'; r <= 'z'; r++ {
-		e.svars = append(e.svars, matrix.NewScalarFrac(0))
+		e.svars = append(e.svars, &Value{VType: SVar, SValue: matrix.NewScalarFrac(0)})
 	}
 
 	return e
 }
 
 // GetMVar returns the value of the given matrix variable.
-// It assumes the given rune is a valid matrix variable name.
+// It assumes the given rune is a valid matrix variable name which holds a matrix of fractions.
 func (e *E) GetMVar(v rune) matrix.M {
-	return e.mvars[v-'A']
+	return e.mvars[v-'A'].MValue
 }
 
 // SetMVar sets the value of the given matrix variable to the given matrix.
 // It assumes the given rune is a valid matrix variable name.
 func (e *E) SetMVar(v rune, m matrix.M) {
-	e.mvars[v-'A'] = m
+	e.mvars[v-'A'] = valueFromMatrix(m)
 }
 
 // GetSVar returns the value of the given scalar variable.
-// It assumes the given rune is a valid scalar variable name.
+// It assumes the given rune is a valid scalar variable name which holds a fraction.
 func (e *E) GetSVar(v rune) matrix.Frac {
-	return e.svars[v-'a']
+	return e.svars[v-'a'].SValue
 }
 
 // SetSVar sets the value of the given scalar variable to the given scalar.
 // It assumes the given rune is a valid scalar variable name.
 func (e *E) SetSVar(v rune, m matrix.Frac) {
-	e.svars[v-'a'] = m.Reduce()
+	e.svars[v-'a'] = &Value{VType: SVar, SValue: m.Reduce()}
+}
+
+// GetVar returns a copy of the value of the given variable.
+// It assumes the given rune is a valid variable name.
+func (e *E) GetVar(v rune) *Value {
+	var val Value
+	if GetVarType(v) == MVar {
+		val = *e.mvars[v-'A']
+	} else {
+		val = *e.svars[v-'a']
+	}
+
+	return &val
+}
+
+// SetVar sets the value of the given variable.
+// It assumes the given rune is a valid variable name whose type (matrix or scalar) matches the value.
+func (e *E) SetVar(v rune, val *Value) {
+	if GetVarType(v) == MVar {
+		e.mvars[v-'A'] = val
+	} else {
+		e.svars[v-'a'] = val
+	}
+}
+
+// Modulus returns the prime modulus expressions are evaluated with, or zero if there is none.
+func (e *E) Modulus() int {
+	return e.modulus
+}
+
+// SetModulus sets the prime modulus that expressions are evaluated with.
+// A modulus of zero returns the environment to rational arithmetic.
+// An error is returned if p is neither zero nor prime, or if the environment is in floating-point mode.
+func (e *E) SetModulus(p int) error {
+	if p != 0 && e.float {
+		return fmt.Errorf("floating-point mode cannot be used modulo a prime")
+	}
+
+	if p != 0 && !matrix.IsPrime(p) {
+		return fmt.Errorf("modulus %d is not prime", p)
+	}
+
+	e.modulus = p
+	return nil
 }
 
 // VarType represents the type of a certain variable.
@@ -78,6 +139,14 @@ type VarType int
 const (
 	MVar VarType = iota
 	SVar
+	PMVar   // polynomial matrix
+	PVar    // polynomial
+	RMVar   // matrix of rational functions in a parameter
+	RVar    // rational function in a parameter
+	FMVar   // floating-point matrix
+	FVar    // floating-point scalar
+	RadMVar // matrix with radical entries, which can only be displayed
+	ExpMVar // vector of exponentials in t, which can only be displayed
 	InvalidVar
 )
 
@@ -87,6 +156,22 @@ func (vt VarType) String() string {
 		return "mvar"
 	case SVar:
 		return "svar"
+	case PMVar:
+		return "pmvar"
+	case PVar:
+		return "pvar"
+	case RMVar:
+		return "rmvar"
+	case RVar:
+		return "rvar"
+	case FMVar:
+		return "fmvar"
+	case FVar:
+		return "fvar"
+	case RadMVar:
+		return "radmvar"
+	case ExpMVar:
+		return "expmvar"
 	case InvalidVar:
 		return "invalid"
 	}
@@ -94,6 +179,11 @@ func (vt VarType) String() string {
 	return "unknown"
 }
 
+// IsMatrix returns true if the type is a kind of matrix, and false if it is a kind of scalar.
+func (vt VarType) IsMatrix() bool {
+	return vt == MVar || vt == PMVar || vt == RMVar || vt == FMVar || vt == RadMVar || vt == ExpMVar
+}
+
 // GetVarType returns the type of variable that the given rune represents.
 // Returns InvalidVar if v does not represent a valid variable.
 func GetVarType(v rune) VarType {
@@ -101,7 +191,7 @@ func GetVarType(v rune) VarType {
 		return MVar
 	}
 
-	if v >= 'a' && v <= 'z' {
+	if v >= 'a' && v <= 'z' && v != lang.Indeterminate {
 		return SVar
 	}
 
@@ -112,35 +202,122 @@ func GetVarType(v rune) VarType {
 type Value struct {
 	VType VarType
 
-	MValue matrix.M
-	SValue matrix.Frac
+	MValue  matrix.M
+	SValue  matrix.Frac
+	PMValue matrix.PM
+	PValue  matrix.Poly
+	RMValue matrix.RM
+	RValue  matrix.RatFunc
+	FMValue matrix.FM
+	FValue  float64
+
+	RadMValue matrix.RadM
+	ExpMValue matrix.ExpVector
+
+	Notes []Note
 }
 
+// A Note is a labelled piece of additional information attached to a value, such as a special case.
+type Note struct {
+	Label string
+	Value *Value // nil if the note is only a label
+}
+
+// MatrixFromEntries builds an r x c matrix value from scalar values given in row order.
+// If any entry is a polynomial, depends on a parameter or is floating-point, the result is a polynomial, symbolic
+// or floating-point matrix. An error is returned if an entry is a matrix or if the entries cannot be combined.
+func MatrixFromEntries(r, c int, entries []*Value) (*Value, error) {
+	poly, symbolic, float := false, false, false
+	for _, val := range entries {
+		if val.VType.IsMatrix() {
+			return nil, fmt.Errorf("matrix entries must be scalars")
+		}
+
+		poly = poly || isPolynomial(val)
+		symbolic = symbolic || isSymbolic(val)
+		float = float || isFloat(val)
+	}
+
+	switch {
+	case float:
+		if err := checkFloat(entries...); err != nil {
+			return nil, err
+		}
+
+		m := matrix.NewFM(r, c)
+		for i, val := range entries {
+			m.Set(i/c+1, i%c+1, toFloat(val).FValue)
+		}
+
+		return &Value{VType: FMVar, FMValue: m}, nil
+	case symbolic:
+		if err := checkSymbolic(entries...); err != nil {
+			return nil, err
+		}
+
+		m := matrix.NewRM(r, c)
+		for i, val := range entries {
+			m.Set(i/c+1, i%c+1, toSymbolic(val).RValue)
+		}
+
+		return &Value{VType: RMVar, RMValue: m}, nil
+	case poly:
+		m := matrix.NewPM(r, c)
+		for i, val := range entries {
+			m.Set(i/c+1, i%c+1, toPolynomial(val).PValue)
+		}
+
+		return &Value{VType: PMVar, PMValue: m}, nil
+	}
+
+	m := matrix.New(r, c)
+	for i, val := range entries {
+		m.Set(i/c+1, i%c+1, val.SValue)
+	}
+
+	return valueFromMatrix(m), nil
+}
+
+// errRadical is returned when a matrix with radical entries is used in arithmetic.
+var errRadical = fmt.Errorf("matrices with radical entries can only be displayed")
+
+// errExponential is returned when the solution of a differential equation is used in arithmetic.
+var errExponential = fmt.Errorf("solutions of differential equations can only be displayed")
+
 // Evaluate evaluates a lang.ExprNode within the context of the given environment, returning an error if one occurs.
 // It also returns a Value which holds the expression result.
 func Evaluate(enode *lang.ExprNode, env *E) (*Value, error) {
+	if enode.Modulus != nil {
+		p, _ := strconv.Atoi(enode.Modulus.Literal)
+
+		defer env.SetModulus(env.modulus)
+
+		err := env.SetModulus(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	val, err := evalExpr(enode, env)
 	if err != nil {
 		return nil, err
 	}
 
+	val, err = env.reduce(val)
+	if err != nil {
+		return nil, err
+	}
+
 	if enode.ResultVar != nil {
-		if val.VType == MVar && enode.ResultVar.TType == lang.TTSVar {
+		if val.VType.IsMatrix() && enode.ResultVar.TType == lang.TTSVar {
 			return nil, fmt.Errorf("cannot assign a matrix value to a scalar variable")
 		}
 
-		if val.VType == SVar && enode.ResultVar.TType == lang.TTMVar {
+		if !val.VType.IsMatrix() && enode.ResultVar.TType == lang.TTMVar {
 			return nil, fmt.Errorf("cannot assign a scalar value to a matrix variable")
 		}
 
-		v := rune(enode.ResultVar.Literal[0])
-
-		switch val.VType {
-		case MVar:
-			env.SetMVar(v, val.MValue)
-		case SVar:
-			env.SetSVar(v, val.SValue)
-		}
+		env.SetVar(rune(enode.ResultVar.Literal[0]), val)
 	}
 
 	return val, nil
@@ -164,16 +341,49 @@ func evalExpr(enode *lang.ExprNode, env *E) (*Value, error) {
 		if err != nil {
 			return nil, err
 		}
+
+		first, err = env.reduce(first)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return first, nil
 }
 
 func evalAddition(subtraction bool, left, right *Value) (*Value, error) {
-	if left.VType != right.VType {
+	if left.VType == RadMVar || right.VType == RadMVar {
+		return nil, errRadical
+	}
+
+	if left.VType == ExpMVar || right.VType == ExpMVar {
+		return nil, errExponential
+	}
+
+	if left.VType.IsMatrix() != right.VType.IsMatrix() {
 		return nil, fmt.Errorf("cannot perform addition or subtraction with a scalar and a matrix")
 	}
 
+	if isFloat(left) || isFloat(right) {
+		if err := checkFloat(left, right); err != nil {
+			return nil, err
+		}
+
+		return evalFloatAddition(subtraction, toFloat(left), toFloat(right))
+	}
+
+	if isSymbolic(left) || isSymbolic(right) {
+		if err := checkSymbolic(left, right); err != nil {
+			return nil, err
+		}
+
+		return evalSymbolicAddition(subtraction, toSymbolic(left), toSymbolic(right))
+	}
+
+	if isPolynomial(left) || isPolynomial(right) {
+		return evalPolyAddition(subtraction, toPolynomial(left), toPolynomial(right))
+	}
+
 	if left.VType == SVar {
 		if subtraction {
 			right.SValue = right.SValue.Neg()
@@ -236,6 +446,11 @@ func evalTerm(tnode *lang.TermNode, env *E) (*Value, error) {
 			return nil, err
 		}
 
+		val, err = env.reduce(val)
+		if err != nil {
+			return nil, err
+		}
+
 		fstack.push(val)
 	}
 
@@ -249,6 +464,11 @@ func evalTerm(tnode *lang.TermNode, env *E) (*Value, error) {
 			return nil, err
 		}
 
+		val, err = env.reduce(val)
+		if err != nil {
+			return nil, err
+		}
+
 		divaccum = val
 	}
 
@@ -256,6 +476,38 @@ func evalTerm(tnode *lang.TermNode, env *E) (*Value, error) {
 }
 
 func evalMultiplication(division bool, left, right *Value) (*Value, error) {
+	if left.VType == RadMVar || right.VType == RadMVar {
+		return nil, errRadical
+	}
+
+	if left.VType == ExpMVar || right.VType == ExpMVar {
+		return nil, errExponential
+	}
+
+	if division && right.VType == SVar && right.SValue.IsZero() {
+		return nil, fmt.Errorf("cannot divide by zero")
+	}
+
+	if isFloat(left) || isFloat(right) {
+		if err := checkFloat(left, right); err != nil {
+			return nil, err
+		}
+
+		return evalFloatMultiplication(division, toFloat(left), toFloat(right))
+	}
+
+	if isSymbolic(left) || isSymbolic(right) {
+		if err := checkSymbolic(left, right); err != nil {
+			return nil, err
+		}
+
+		return evalSymbolicMultiplication(division, toSymbolic(left), toSymbolic(right))
+	}
+
+	if isPolynomial(left) || isPolynomial(right) {
+		return evalPolyMultiplication(division, toPolynomial(left), toPolynomial(right))
+	}
+
 	if left.VType == SVar && right.VType == SVar {
 		rrec := right.SValue
 		if division {
@@ -301,27 +553,101 @@ func evalFactor(fnode *lang.FactorNode, env *E) (*Value, error) {
 		return nil, err
 	}
 
+	if fnode.FType == lang.NumFactor || fnode.FType == lang.VarFactor {
+		val = env.approximate(val)
+	}
+
 	if fnode.Neg != nil {
 		switch val.VType {
 		case MVar:
 			val.MValue = matrix.Scale(matrix.NewScalarFrac(-1), val.MValue)
 		case SVar:
 			val.SValue = val.SValue.Mul(matrix.NewScalarFrac(-1))
+		case PMVar:
+			val.PMValue = matrix.ScalePM(matrix.NewScalarPoly(matrix.NewScalarFrac(-1)), val.PMValue)
+		case PVar:
+			val.PValue = val.PValue.Neg()
+		case RMVar:
+			val.RMValue = matrix.ScaleRM(matrix.RatFuncFromFrac(matrix.NewScalarFrac(-1)), val.RMValue)
+		case RVar:
+			val.RValue = val.RValue.Neg()
+		case FMVar:
+			val.FMValue = matrix.ScaleFM(-1, val.FMValue)
+		case FVar:
+			val.FValue = -val.FValue
+		case RadMVar:
+			val.RadMValue = matrix.ScaleRadM(matrix.NewScalarFrac(-1), val.RadMValue)
+		case ExpMVar:
+			val.ExpMValue = val.ExpMValue.Scale(matrix.NewScalarFrac(-1))
 		}
 	}
 
 	return val, nil
 }
 
+// reduce reduces a value modulo the environment's modulus, if one is set. Its notes are kept.
+func (e *E) reduce(val *Value) (*Value, error) {
+	if e.modulus == 0 {
+		return val, nil
+	}
+
+	switch val.VType {
+	case MVar:
+		m, err := matrix.ReduceMod(val.MValue, e.modulus)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Value{VType: MVar, MValue: m, Notes: val.Notes}, nil
+	case SVar:
+		f, err := val.SValue.Mod(e.modulus)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Value{VType: SVar, SValue: f, Notes: val.Notes}, nil
+	case PMVar:
+		m, err := matrix.ReduceModPM(val.PMValue, e.modulus)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Value{VType: PMVar, PMValue: m, Notes: val.Notes}, nil
+	case PVar:
+		p, err := val.PValue.Mod(e.modulus)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Value{VType: PVar, PValue: p, Notes: val.Notes}, nil
+	case RMVar, RVar:
+		return nil, fmt.Errorf("symbolic parameters cannot be used modulo a prime")
+	case FMVar, FVar:
+		return nil, fmt.Errorf("floating-point values cannot be used modulo a prime")
+	case RadMVar:
+		return nil, fmt.Errorf("radicals cannot be used modulo a prime")
+	case ExpMVar:
+		return nil, fmt.Errorf("exponentials cannot be used modulo a prime")
+	}
+
+	return val, nil
+}
+
 func evalFactorIgnoreNeg(fnode *lang.FactorNode, env *E) (*Value, error) {
 	switch fnode.FType {
 	case lang.NumFactor:
 		num, _ := strconv.Atoi(fnode.Num.Literal)
 		return &Value{VType: SVar, SValue: matrix.NewScalarFrac(num)}, nil
+	case lang.IndetFactor:
+		return &Value{VType: PVar, PValue: matrix.PolyX()}, nil
 	case lang.ParenFactor:
 		return evalExpr(fnode.ParenExpr, env)
 	case lang.FuncFactor:
 		return evalFunction(fnode, env)
+	case lang.BlockFactor:
+		return evalBlock(fnode, env)
+	case lang.StringFactor:
+		return nil, fmt.Errorf("text in quotes is not a value; it can only be given to commands such as hill")
 	}
 
 	switch fnode.Variable.TType {
@@ -331,8 +657,8 @@ func evalFactorIgnoreNeg(fnode *lang.FactorNode, env *E) (*Value, error) {
 		if !ok {
 			return nil, fmt.Errorf("user cancelled matrix input")
 		}
-		env.SetMVar(v, mat)
-		return &Value{VType: MVar, MValue: mat}, nil
+		env.SetVar(v, mat)
+		return env.GetVar(v), nil
 	case lang.TTDSVar:
 		v := rune(fnode.Variable.Literal[1])
 		scal, ok := env.sdef(v)
@@ -346,15 +672,9 @@ func evalFactorIgnoreNeg(fnode *lang.FactorNode, env *E) (*Value, error) {
 		if !ok {
 			return nil, fmt.Errorf("user cancelled matrix input")
 		}
-		return &Value{VType: MVar, MValue: mat}, nil
-	case lang.TTMVar:
-		v := rune(fnode.Variable.Literal[0])
-		mat := env.GetMVar(v)
-		return &Value{VType: MVar, MValue: mat}, nil
-	case lang.TTSVar:
-		v := rune(fnode.Variable.Literal[0])
-		scal := env.GetSVar(v)
-		return &Value{VType: SVar, SValue: scal}, nil
+		return mat, nil
+	case lang.TTMVar, lang.TTSVar:
+		return env.GetVar(rune(fnode.Variable.Literal[0])), nil
 	}
 
 	return nil, fmt.Errorf("unexpected factor")
@@ -363,12 +683,8 @@ func evalFactorIgnoreNeg(fnode *lang.FactorNode, env *E) (*Value, error) {
 func evalFunction(fnode *lang.FactorNode, env *E) (*Value, error) {
 	fname := fnode.Function.Literal
 
-	fn, ok := functions[fname]
-	if !ok {
-		return nil, fmt.Errorf("%q is not a valid function", fname)
-	}
-
 	vals := []*Value{}
+	poly, symbolic, float := false, false, false
 
 	for _, enode := range fnode.FuncArgs {
 		val, err := evalExpr(enode, env)
@@ -377,6 +693,56 @@ func evalFunction(fnode *lang.FactorNode, env *E) (*Value, error) {
 		}
 
 		vals = append(vals, val)
+		poly = poly || isPolynomial(val)
+		symbolic = symbolic || isSymbolic(val)
+		float = float || isFloat(val)
+	}
+
+	fn, ok := functions[fname]
+	switch {
+	case float:
+		if err := checkFloat(vals...); err != nil {
+			return nil, err
+		}
+
+		fn, ok = floatFunctions[fname]
+		if _, exists := functions[fname]; !ok && exists {
+			return nil, fmt.Errorf("%s does not accept floating-point arguments", fname)
+		}
+
+		for i, val := range vals {
+			vals[i] = toFloat(val)
+		}
+	case symbolic:
+		if err := checkSymbolic(vals...); err != nil {
+			return nil, err
+		}
+
+		fn, ok = symbolicFunctions[fname]
+		if _, exists := functions[fname]; !ok && exists {
+			return nil, fmt.Errorf("%s does not accept symbolic arguments", fname)
+		}
+
+		for i, val := range vals {
+			vals[i] = toSymbolic(val)
+		}
+	case poly:
+		fn, ok = polyFunctions[fname]
+		if _, exists := functions[fname]; !ok && exists {
+			return nil, fmt.Errorf("%s does not accept polynomial arguments", fname)
+		}
+
+		for i, val := range vals {
+			vals[i] = toPolynomial(val)
+		}
+	}
+
+	if !ok {
+		if _, exists := polyFunctions[fname]; exists && !poly {
+			return nil, fmt.Errorf("%s takes a polynomial in %c, such as %c*%c - 1, as its first argument", fname, lang.Indeterminate, lang.Indeterminate, lang.Indeterminate)
+		}
+
+		return nil, fmt.Errorf("%q is not a valid function", fname)
 	}
 
 	err := checkFunctionArgs(vals, fname, fn)
@@ -384,7 +750,133 @@ func evalFunction(fnode *lang.FactorNode, env *E) (*Value, error) {
 		return nil, err
 	}
 
-	return fn.handler(vals)
+	// Only the result is reduced: arguments are often sizes, row numbers, exponents or lists of them, and the
+	// functions which divide reduce their matrices themselves.
+	val, err := fn.handler(env, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	return env.reduce(val)
+}
+
+// evalBlock assembles a block matrix literal. Scalars stand for 1x1 blocks.
+// The blocks are promoted to a common type the same way the operands of an addition are.
+func evalBlock(fnode *lang.FactorNode, env *E) (*Value, error) {
+	rows := [][]*Value{}
+	all := []*Value{}
+	float, symbolic, poly := false, false, false
+
+	for i, row := range fnode.Blocks {
+		rows = append(rows, []*Value{})
+
+		for _, enode := range row {
+			val, err := evalExpr(enode, env)
+			if err != nil {
+				return nil, err
+			}
+
+			switch val.VType {
+			case RadMVar:
+				return nil, errRadical
+			case ExpMVar:
+				return nil, errExponential
+			}
+
+			rows[i] = append(rows[i], val)
+			all = append(all, val)
+
+			float = float || isFloat(val)
+			symbolic = symbolic || isSymbolic(val)
+			poly = poly || isPolynomial(val)
+		}
+	}
+
+	switch {
+	case float:
+		if err := checkFloat(all...); err != nil {
+			return nil, err
+		}
+
+		m, err := matrix.BlockFM(blocksOf(rows, func(val *Value) matrix.FM {
+			val = toFloat(val)
+			if val.VType == FMVar {
+				return val.FMValue
+			}
+
+			b := matrix.NewFM(1, 1)
+			b.Set(1, 1, val.FValue)
+			return b
+		}))
+		if err != nil {
+			return nil, err
+		}
+
+		return &Value{VType: FMVar, FMValue: m}, nil
+	case symbolic:
+		if err := checkSymbolic(all...); err != nil {
+			return nil, err
+		}
+
+		m, err := matrix.BlockRM(blocksOf(rows, func(val *Value) matrix.RM {
+			val = toSymbolic(val)
+			if val.VType == RMVar {
+				return val.RMValue
+			}
+
+			b := matrix.NewRM(1, 1)
+			b.Set(1, 1, val.RValue)
+			return b
+		}))
+		if err != nil {
+			return nil, err
+		}
+
+		return &Value{VType: RMVar, RMValue: m}, nil
+	case poly:
+		m, err := matrix.BlockPM(blocksOf(rows, func(val *Value) matrix.PM {
+			val = toPolynomial(val)
+			if val.VType == PMVar {
+				return val.PMValue
+			}
+
+			b := matrix.NewPM(1, 1)
+			b.Set(1, 1, val.PValue)
+			return b
+		}))
+		if err != nil {
+			return nil, err
+		}
+
+		return &Value{VType: PMVar, PMValue: m}, nil
+	}
+
+	m, err := matrix.Block(blocksOf(rows, func(val *Value) matrix.M {
+		if val.VType == MVar {
+			return val.MValue
+		}
+
+		b := matrix.New(1, 1)
+		b.Set(1, 1, val.SValue)
+		return b
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Value{VType: MVar, MValue: m}, nil
+}
+
+// blocksOf converts each value of a block literal to a block with the given function.
+func blocksOf[B any](rows [][]*Value, block func(*Value) B) [][]B {
+	blocks := make([][]B, len(rows))
+	for i, row := range rows {
+		for _, val := range row {
+			blocks[i] = append(blocks[i], block(val))
+		}
+	}
+
+	return blocks
 }
 
 type vstack struct {
@@ -1,5 +1,7 @@
 package env
 
+import "math"
+import "strings"
 import "testing"
 import "github.com/layneson/rowsofb/lang"
 import "github.com/layneson/rowsofb/matrix"
@@ -131,9 +133,371 @@ func buildNumFactor(num string) *lang.FactorNode {
 	}
 }
 
+func buildVarFactor(v string) *lang.FactorNode {
+	ttype := lang.TTSVar
+	if v >= "A" && v <= "Z" {
+		ttype = lang.TTMVar
+	}
+
+	return &lang.FactorNode{
+		FType: lang.VarFactor,
+		Variable: &lang.Token{
+			Literal: v,
+			TType:   ttype,
+		},
+	}
+}
+
+func buildIndetFactor() *lang.FactorNode {
+	return &lang.FactorNode{
+		FType: lang.IndetFactor,
+		Indet: &lang.Token{
+			Literal: "x",
+			TType:   lang.TTIndet,
+		},
+	}
+}
+
 func buildParenFactor(expr *lang.ExprNode) *lang.FactorNode {
 	return &lang.FactorNode{
 		FType:     lang.ParenFactor,
 		ParenExpr: expr,
 	}
 }
+
+func buildFuncFactor(name string, args ...*lang.FactorNode) *lang.FactorNode {
+	fnode := &lang.FactorNode{
+		FType: lang.FuncFactor,
+		Function: &lang.Token{
+			Literal: name,
+			TType:   lang.TTFunc,
+		},
+	}
+
+	for _, f := range args {
+		fnode.FuncArgs = append(fnode.FuncArgs, buildExpr(buildTerm(f).term).expr)
+	}
+
+	return fnode
+}
+
+func buildBlockFactor(rows ...[]*lang.FactorNode) *lang.FactorNode {
+	fnode := &lang.FactorNode{FType: lang.BlockFactor}
+
+	for _, row := range rows {
+		exprs := []*lang.ExprNode{}
+		for _, f := range row {
+			exprs = append(exprs, buildExpr(buildTerm(f).term).expr)
+		}
+
+		fnode.Blocks = append(fnode.Blocks, exprs)
+	}
+
+	return fnode
+}
+
+func TestEvaluatePolynomial(t *testing.T) {
+	// (x + 1) * (x - 1) / 2
+	input := buildExpr(
+		buildTerm(buildParenFactor(
+			buildExpr(buildTerm(buildIndetFactor()).term).
+				add(buildTerm(buildNumFactor("1")).term).expr,
+		)).mult(buildParenFactor(
+			buildExpr(buildTerm(buildIndetFactor()).term).
+				sub(buildTerm(buildNumFactor("1")).term).expr,
+		)).div(buildNumFactor("2")).term,
+	).expr
+
+	output, err := Evaluate(input, New(nil, nil, nil))
+	if err != nil {
+		t.Fatalf("call to Evaluate failed with error: %v", err)
+	}
+
+	if output.VType != PVar {
+		t.Fatalf("expected output vtype %s but got %s", PVar, output.VType)
+	}
+
+	expected := matrix.NewPoly(matrix.NewFrac(-1, 2), matrix.NewScalarFrac(0), matrix.NewFrac(1, 2))
+	if !output.PValue.Equals(expected) {
+		t.Fatalf("expected output %s but got %s", expected, output.PValue)
+	}
+
+	// x / (x - x)
+	input = buildExpr(
+		buildTerm(buildIndetFactor()).div(buildParenFactor(
+			buildExpr(buildTerm(buildIndetFactor()).term).
+				sub(buildTerm(buildIndetFactor()).term).expr,
+		)).term,
+	).expr
+
+	if _, err := Evaluate(input, New(nil, nil, nil)); err == nil || err.Error() != "cannot divide by zero" {
+		t.Fatalf("expected division by the zero polynomial to fail but got %v", err)
+	}
+}
+
+func TestEvaluateParam(t *testing.T) {
+	// (k * k - 1) / (k - 1)
+	input := buildExpr(
+		buildTerm(buildParenFactor(
+			buildExpr(buildTerm(buildVarFactor("k")).mult(buildVarFactor("k")).term).
+				sub(buildTerm(buildNumFactor("1")).term).expr,
+		)).div(buildParenFactor(
+			buildExpr(buildTerm(buildVarFactor("k")).term).
+				sub(buildTerm(buildNumFactor("1")).term).expr,
+		)).term,
+	).expr
+
+	e := New(nil, nil, nil)
+	e.SetParam('k')
+
+	output, err := Evaluate(input, e)
+	if err != nil {
+		t.Fatalf("call to Evaluate failed with error: %v", err)
+	}
+
+	if output.VType != RVar {
+		t.Fatalf("expected output vtype %s but got %s", RVar, output.VType)
+	}
+
+	if output.RValue.String() != "k + 1" {
+		t.Fatalf("expected output k + 1 but got %s", output.RValue)
+	}
+
+	e.SetSVar('k', matrix.NewScalarFrac(3))
+
+	output, err = Evaluate(input, e)
+	if err != nil {
+		t.Fatalf("call to Evaluate failed with error: %v", err)
+	}
+
+	if output.VType != SVar || !output.SValue.Equals(matrix.NewScalarFrac(4)) {
+		t.Fatalf("expected output 4 but got %s", output.SValue)
+	}
+}
+
+func TestEvaluateModulus(t *testing.T) {
+	input := buildExpr(
+		buildTerm(buildNumFactor("3")).
+			div(buildNumFactor("4")).term,
+	).add(buildTerm(buildNumFactor("5")).term).expr
+
+	input.Modulus = &lang.Token{Literal: "7", TType: lang.TTNum}
+
+	e := New(nil, nil, nil)
+
+	output, err := Evaluate(input, e)
+	if err != nil {
+		t.Fatalf("call to Evaluate failed with error: %v", err)
+	}
+
+	if !output.SValue.Equals(matrix.NewScalarFrac(4)) { // 3 * 4^-1 + 5 = 3 * 2 + 5 = 11 = 4 (mod 7)
+		t.Fatalf("expected output 4 but got %s", output.SValue)
+	}
+
+	if e.Modulus() != 0 {
+		t.Fatalf("expression modulus leaked into the environment")
+	}
+
+	input.Modulus.Literal = "8"
+
+	if _, err := Evaluate(input, e); err == nil {
+		t.Fatalf("evaluating modulo a composite number must fail")
+	}
+}
+
+func TestEvaluateModulusFunction(t *testing.T) {
+	e := New(nil, nil, nil)
+
+	// identity(4) mod 3 must not reduce the size 4 to 1
+	input := buildExpr(buildTerm(buildFuncFactor("identity", buildNumFactor("4"))).term).expr
+	input.Modulus = &lang.Token{Literal: "3", TType: lang.TTNum}
+
+	output, err := Evaluate(input, e)
+	if err != nil {
+		t.Fatalf("call to Evaluate failed with error: %v", err)
+	}
+
+	if output.VType != MVar || !output.MValue.Equals(matrix.Identity(4)) {
+		t.Fatalf("expected the 4x4 identity but got\n%v", output.MValue)
+	}
+
+	// hamming(3) mod 2 must keep its 3 parity bits, and the notes of the reduced result must survive
+	input = buildExpr(buildTerm(buildFuncFactor("hamming", buildNumFactor("3"))).term).expr
+	input.Modulus = &lang.Token{Literal: "2", TType: lang.TTNum}
+
+	output, err = Evaluate(input, e)
+	if err != nil {
+		t.Fatalf("call to Evaluate failed with error: %v", err)
+	}
+
+	if output.MValue.Rows() != 7 || output.MValue.Cols() != 4 {
+		t.Fatalf("expected a 7x4 generator matrix but got\n%v", output.MValue)
+	}
+
+	if len(output.Notes) == 0 {
+		t.Fatalf("notes were lost when reducing the result")
+	}
+
+	// pow(A, n) mod 2 with n = -1 must invert modulo 2, where A = [1, 1; 1, 3] is singular
+	e.SetVar('A', valueFromMatrix(matrix.NewWithValues(2, 2, []matrix.Frac{
+		matrix.NewScalarFrac(1), matrix.NewScalarFrac(1),
+		matrix.NewScalarFrac(1), matrix.NewScalarFrac(3),
+	})))
+	e.SetSVar('n', matrix.NewScalarFrac(-1))
+
+	input = buildExpr(buildTerm(buildFuncFactor("pow", buildVarFactor("A"), buildVarFactor("n"))).term).expr
+	input.Modulus = &lang.Token{Literal: "2", TType: lang.TTNum}
+
+	if _, err := Evaluate(input, e); err == nil || !strings.Contains(err.Error(), "no inverse modulo 2") {
+		t.Fatalf("expected the matrix to have no inverse modulo 2 but got %v", err)
+	}
+}
+
+func TestEvaluateFloat(t *testing.T) {
+	// 1 / 3 + a
+	input := buildExpr(
+		buildTerm(buildNumFactor("1")).
+			div(buildNumFactor("3")).term,
+	).add(buildTerm(buildVarFactor("a")).term).expr
+
+	e := New(nil, nil, nil)
+	e.SetSVar('a', matrix.NewFrac(1, 6))
+
+	if err := e.SetFloatMode(6, 1e-10); err != nil {
+		t.Fatalf("call to SetFloatMode failed with error: %v", err)
+	}
+
+	output, err := Evaluate(input, e)
+	if err != nil {
+		t.Fatalf("call to Evaluate failed with error: %v", err)
+	}
+
+	if output.VType != FVar {
+		t.Fatalf("expected output vtype %s but got %s", FVar, output.VType)
+	}
+
+	if math.Abs(output.FValue-0.5) > 1e-12 {
+		t.Fatalf("expected output 0.5 but got %v", output.FValue)
+	}
+
+	if err := e.SetModulus(7); err == nil {
+		t.Fatalf("setting a modulus in floating-point mode must fail")
+	}
+
+	e.SetExactMode()
+
+	output, err = Evaluate(input, e)
+	if err != nil {
+		t.Fatalf("call to Evaluate failed with error: %v", err)
+	}
+
+	if output.VType != SVar || !output.SValue.Equals(matrix.NewFrac(1, 2)) {
+		t.Fatalf("expected output 1/2 but got %s", output.SValue)
+	}
+}
+
+func TestEvaluateBlock(t *testing.T) {
+	// [A, B; 0, 1]
+	input := buildExpr(buildTerm(buildBlockFactor(
+		[]*lang.FactorNode{buildVarFactor("A"), buildVarFactor("B")},
+		[]*lang.FactorNode{buildNumFactor("0"), buildNumFactor("1")},
+	)).term).expr
+
+	e := New(nil, nil, nil)
+	e.SetVar('A', valueFromMatrix(matrix.Identity(1)))
+	e.SetVar('B', valueFromMatrix(matrix.Scale(matrix.NewScalarFrac(2), matrix.Identity(1))))
+
+	output, err := Evaluate(input, e)
+	if err != nil {
+		t.Fatalf("call to Evaluate failed with error: %v", err)
+	}
+
+	expected := matrix.NewWithValues(2, 2, []matrix.Frac{
+		matrix.NewScalarFrac(1), matrix.NewScalarFrac(2),
+		matrix.NewScalarFrac(0), matrix.NewScalarFrac(1),
+	})
+
+	if output.VType != MVar || !output.MValue.Equals(expected) {
+		t.Fatalf("expected output\n%v\nbut got\n%v", expected, output.MValue)
+	}
+
+	e.SetVar('B', valueFromMatrix(matrix.Identity(2)))
+
+	if _, err := Evaluate(input, e); err == nil || !strings.Contains(err.Error(), "block (1,2)") {
+		t.Fatalf("expected an error naming block (1,2) but got %v", err)
+	}
+}
+
+func TestEvaluateBlockPromotion(t *testing.T) {
+	// [1, 2; 3, 4]
+	input := buildExpr(buildTerm(buildBlockFactor(
+		[]*lang.FactorNode{buildNumFactor("1"), buildNumFactor("2")},
+		[]*lang.FactorNode{buildNumFactor("3"), buildNumFactor("4")},
+	)).term).expr
+
+	e := New(nil, nil, nil)
+	if err := e.SetFloatMode(6, 1e-10); err != nil {
+		t.Fatalf("call to SetFloatMode failed with error: %v", err)
+	}
+
+	output, err := Evaluate(input, e)
+	if err != nil {
+		t.Fatalf("call to Evaluate failed with error: %v", err)
+	}
+
+	if output.VType != FMVar {
+		t.Fatalf("expected output vtype %s but got %s", FMVar, output.VType)
+	}
+
+	for i, expected := range []float64{1, 2, 3, 4} {
+		if v := output.FMValue.Get(i/2+1, i%2+1); v != expected {
+			t.Fatalf("expected entry (%d,%d) to be %v but got %v", i/2+1, i%2+1, expected, v)
+		}
+	}
+
+	// [x, 1; 0, x]
+	input = buildExpr(buildTerm(buildBlockFactor(
+		[]*lang.FactorNode{buildIndetFactor(), buildNumFactor("1")},
+		[]*lang.FactorNode{buildNumFactor("0"), buildIndetFactor()},
+	)).term).expr
+
+	output, err = Evaluate(input, New(nil, nil, nil))
+	if err != nil {
+		t.Fatalf("call to Evaluate failed with error: %v", err)
+	}
+
+	if output.VType != PMVar {
+		t.Fatalf("expected output vtype %s but got %s", PMVar, output.VType)
+	}
+
+	expected := matrix.NewPoly(matrix.NewScalarFrac(0), matrix.NewScalarFrac(0), matrix.NewScalarFrac(1))
+	if det, _ := matrix.DeterminantPM(output.PMValue); !det.Equals(expected) {
+		t.Fatalf("expected determinant %s but got %s", expected, det)
+	}
+}
+
+func TestRandomSeed(t *testing.T) {
+	args := []*Value{
+		{VType: SVar, SValue: matrix.NewScalarFrac(3)},
+		{VType: SVar, SValue: matrix.NewScalarFrac(-1)},
+	}
+
+	e := New(nil, nil, nil)
+
+	e.SetSeed(42)
+	first, err := functions["randdet"].handler(e, args)
+	if err != nil {
+		t.Fatalf("call to randdet failed with error: %v", err)
+	}
+
+	e.SetSeed(42)
+	second, _ := functions["randdet"].handler(e, args)
+
+	if !first.MValue.Equals(second.MValue) {
+		t.Fatalf("the same seed produced different matrices:\n%v\n%v", first.MValue, second.MValue)
+	}
+
+	if det, _ := matrix.Determinant(first.MValue); !det.Equals(matrix.NewScalarFrac(-1)) {
+		t.Fatalf("expected determinant -1 but got %v", det)
+	}
+}
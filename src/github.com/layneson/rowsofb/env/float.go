@@ -0,0 +1,142 @@
+package env
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/layneson/rowsofb/matrix"
+)
+
+// FloatMode returns true if numbers and variables are approximated with floating-point values during evaluation.
+func (e *E) FloatMode() bool {
+	return e.float
+}
+
+// Precision returns the number of significant digits floating-point results should be displayed with.
+func (e *E) Precision() int {
+	return e.precision
+}
+
+// Tolerance returns the magnitude below which floating-point values are treated as zero.
+func (e *E) Tolerance() float64 {
+	return e.tolerance
+}
+
+// SetFloatMode switches the environment to floating-point evaluation, with the given display precision
+// in significant digits and zero tolerance. An error is returned if a modulus is set or if either setting is out of range.
+func (e *E) SetFloatMode(precision int, tolerance float64) error {
+	if e.modulus != 0 {
+		return fmt.Errorf("floating-point mode cannot be used modulo a prime")
+	}
+
+	if precision < 1 || precision > 17 {
+		return fmt.Errorf("precision must be between 1 and 17 digits")
+	}
+
+	if tolerance < 0 || math.IsNaN(tolerance) || math.IsInf(tolerance, 0) {
+		return fmt.Errorf("tolerance must be a non-negative number")
+	}
+
+	e.float = true
+	e.precision = precision
+	e.tolerance = tolerance
+
+	return nil
+}
+
+// SetExactMode returns the environment to exact evaluation. Values which are already floating-point stay approximate.
+func (e *E) SetExactMode() {
+	e.float = false
+}
+
+// isFloat returns true if the value holds a floating-point scalar or matrix.
+func isFloat(val *Value) bool {
+	return val.VType == FVar || val.VType == FMVar
+}
+
+// checkFloat returns an error if any of the given values cannot be approximated with floating-point values.
+func checkFloat(vals ...*Value) error {
+	for _, val := range vals {
+		if isPolynomial(val) || isSymbolic(val) {
+			return fmt.Errorf("cannot combine floating-point values with polynomials or symbolic parameters")
+		}
+	}
+
+	return nil
+}
+
+// toFloat promotes a fraction to a floating-point scalar and a matrix, including one with radical entries, to a floating-point matrix.
+// Values which are already floating-point are returned unchanged.
+func toFloat(val *Value) *Value {
+	switch val.VType {
+	case SVar:
+		return &Value{VType: FVar, FValue: val.SValue.Float()}
+	case MVar:
+		return &Value{VType: FMVar, FMValue: matrix.FMFromMatrix(val.MValue)}
+	case RadMVar:
+		return &Value{VType: FMVar, FMValue: val.RadMValue.Float()}
+	}
+
+	return val
+}
+
+// approximate promotes an exact value to floating-point if the environment is in floating-point mode.
+func (e *E) approximate(val *Value) *Value {
+	if !e.float {
+		return val
+	}
+
+	return toFloat(val)
+}
+
+func evalFloatAddition(subtraction bool, left, right *Value) (*Value, error) {
+	if left.VType == FVar {
+		if subtraction {
+			right.FValue = -right.FValue
+		}
+
+		return &Value{VType: FVar, FValue: left.FValue + right.FValue}, nil
+	}
+
+	if subtraction {
+		right.FMValue = matrix.ScaleFM(-1, right.FMValue)
+	}
+
+	sum, err := matrix.AddFM(left.FMValue, right.FMValue)
+	if err != nil {
+		return nil, fmt.Errorf("cannot perform addition or subtraction on two matrices of different sizes")
+	}
+
+	return &Value{VType: FMVar, FMValue: sum}, nil
+}
+
+func evalFloatMultiplication(division bool, left, right *Value) (*Value, error) {
+	if division {
+		if right.VType == FMVar {
+			return nil, fmt.Errorf("cannot divide by a matrix")
+		}
+
+		if right.FValue == 0 {
+			return nil, fmt.Errorf("cannot divide by zero")
+		}
+
+		right.FValue = 1 / right.FValue
+	}
+
+	switch {
+	case left.VType == FVar && right.VType == FVar:
+		return &Value{VType: FVar, FValue: left.FValue * right.FValue}, nil
+	case left.VType == FVar && right.VType == FMVar:
+		return &Value{VType: FMVar, FMValue: matrix.ScaleFM(left.FValue, right.FMValue)}, nil
+	case left.VType == FMVar && right.VType == FVar:
+		return &Value{VType: FMVar, FMValue: matrix.ScaleFM(right.FValue, left.FMValue)}, nil
+	}
+
+	if left.FMValue.Cols() != right.FMValue.Rows() {
+		return nil, fmt.Errorf("cannot multiply a %dx%d matrix by a %dx%d matrix", left.FMValue.Rows(), left.FMValue.Cols(), right.FMValue.Rows(), right.FMValue.Cols())
+	}
+
+	product, _ := matrix.MultiplyFM(left.FMValue, right.FMValue)
+
+	return &Value{VType: FMVar, FMValue: product}, nil
+}
@@ -2,6 +2,8 @@ package env
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/layneson/rowsofb/matrix"
@@ -10,14 +12,14 @@ import (
 type function struct {
 	signature []VarType
 	vnames    []string
-	handler   func([]*Value) (*Value, error)
+	handler   func(*E, []*Value) (*Value, error)
 }
 
 var functions = map[string]function{
 	"identity": function{
 		[]VarType{SVar},
 		[]string{"size"},
-		func(vals []*Value) (*Value, error) {
+		func(e *E, vals []*Value) (*Value, error) {
 			if !vals[0].SValue.IsWhole() {
 				return nil, fmt.Errorf("size must be an integer")
 			}
@@ -32,40 +34,1492 @@ var functions = map[string]function{
 		},
 	},
 
+	"ones": function{
+		[]VarType{SVar, SVar},
+		[]string{"rows", "cols"},
+		func(e *E, vals []*Value) (*Value, error) {
+			r, c, err := sizeArgs(vals[0].SValue, vals[1].SValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(matrix.Ones(r, c)), nil
+		},
+	},
+
+	"diagm": function{
+		[]VarType{MVar},
+		[]string{"v"},
+		func(e *E, vals []*Value) (*Value, error) {
+			v, err := matrix.VectorEntries(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(matrix.Diagonal(v)), nil
+		},
+	},
+
+	"vander": function{
+		[]VarType{MVar},
+		[]string{"v"},
+		func(e *E, vals []*Value) (*Value, error) {
+			v, err := matrix.VectorEntries(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(matrix.Vandermonde(v)), nil
+		},
+	},
+
+	"hilbert": function{
+		[]VarType{SVar},
+		[]string{"size"},
+		func(e *E, vals []*Value) (*Value, error) {
+			n, err := sizeArg(vals[0].SValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(matrix.Hilbert(n)), nil
+		},
+	},
+
+	"toeplitz": function{
+		[]VarType{MVar, MVar},
+		[]string{"col", "row"},
+		func(e *E, vals []*Value) (*Value, error) {
+			c, err := matrix.VectorEntries(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := matrix.VectorEntries(vals[1].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			m, err := matrix.Toeplitz(c, r)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(m), nil
+		},
+	},
+
+	"circulant": function{
+		[]VarType{MVar},
+		[]string{"v"},
+		func(e *E, vals []*Value) (*Value, error) {
+			v, err := matrix.VectorEntries(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(matrix.Circulant(v)), nil
+		},
+	},
+
+	"perm": function{
+		[]VarType{MVar},
+		[]string{"order"},
+		func(e *E, vals []*Value) (*Value, error) {
+			v, err := matrix.VectorEntries(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			order := []int{}
+			for _, f := range v {
+				if !f.IsWhole() {
+					return nil, fmt.Errorf("row numbers must be integers")
+				}
+
+				order = append(order, f.Reduce().Integer())
+			}
+
+			m, err := matrix.Permutation(order)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(m), nil
+		},
+	},
+
+	"eswap": function{
+		[]VarType{SVar, SVar, SVar},
+		[]string{"size", "i", "j"},
+		func(e *E, vals []*Value) (*Value, error) {
+			n, rows, err := elementaryArgs(vals)
+			if err != nil {
+				return nil, err
+			}
+
+			return elementaryValue(n, matrix.RowOp[matrix.Frac]{Kind: matrix.SwitchOp, R1: rows[0], R2: rows[1]}), nil
+		},
+	},
+
+	"escale": function{
+		[]VarType{SVar, SVar, SVar},
+		[]string{"size", "i", "s"},
+		func(e *E, vals []*Value) (*Value, error) {
+			n, rows, err := elementaryArgs(vals[:2])
+			if err != nil {
+				return nil, err
+			}
+
+			if vals[2].SValue.IsZero() {
+				return nil, fmt.Errorf("an elementary matrix cannot scale a row by zero")
+			}
+
+			return elementaryValue(n, matrix.RowOp[matrix.Frac]{Kind: matrix.ScaleOp, R1: rows[0], Scalar: vals[2].SValue}), nil
+		},
+	},
+
+	"eadd": function{
+		[]VarType{SVar, SVar, SVar, SVar},
+		[]string{"size", "i", "j", "s"},
+		func(e *E, vals []*Value) (*Value, error) {
+			n, rows, err := elementaryArgs(vals[:3])
+			if err != nil {
+				return nil, err
+			}
+
+			if rows[0] == rows[1] {
+				return nil, fmt.Errorf("an elementary matrix cannot add a row to itself")
+			}
+
+			// Ri → Ri + sRj
+			return elementaryValue(n, matrix.RowOp[matrix.Frac]{Kind: matrix.AddOp, R1: rows[1], R2: rows[0], Scalar: vals[3].SValue}), nil
+		},
+	},
+
+	"rand": function{
+		[]VarType{SVar, SVar, SVar, SVar},
+		[]string{"rows", "cols", "lo", "hi"},
+		func(e *E, vals []*Value) (*Value, error) {
+			r, c, err := sizeArgs(vals[0].SValue, vals[1].SValue)
+			if err != nil {
+				return nil, err
+			}
+
+			lo, err := integerArg(vals[2].SValue, "lower bound")
+			if err != nil {
+				return nil, err
+			}
+
+			hi, err := integerArg(vals[3].SValue, "upper bound")
+			if err != nil {
+				return nil, err
+			}
+
+			if lo > hi {
+				return nil, fmt.Errorf("lower bound %d is greater than upper bound %d", lo, hi)
+			}
+
+			return valueFromMatrix(matrix.RandomInt(e.rng, r, c, lo, hi)), nil
+		},
+	},
+
+	"randdet": function{
+		[]VarType{SVar, SVar},
+		[]string{"size", "det"},
+		func(e *E, vals []*Value) (*Value, error) {
+			n, err := sizeArg(vals[0].SValue)
+			if err != nil {
+				return nil, err
+			}
+
+			det, err := integerArg(vals[1].SValue, "determinant")
+			if err != nil {
+				return nil, err
+			}
+
+			m, err := matrix.RandomWithDeterminant(e.rng, n, det)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(m), nil
+		},
+	},
+
+	"randrank": function{
+		[]VarType{SVar, SVar, SVar},
+		[]string{"rows", "cols", "rank"},
+		func(e *E, vals []*Value) (*Value, error) {
+			r, c, err := sizeArgs(vals[0].SValue, vals[1].SValue)
+			if err != nil {
+				return nil, err
+			}
+
+			k, err := integerArg(vals[2].SValue, "rank")
+			if err != nil {
+				return nil, err
+			}
+
+			m, err := matrix.RandomWithRank(e.rng, r, c, k)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(m), nil
+		},
+	},
+
+	"randrref": function{
+		[]VarType{SVar, SVar, SVar},
+		[]string{"rows", "cols", "rank"},
+		func(e *E, vals []*Value) (*Value, error) {
+			r, c, err := sizeArgs(vals[0].SValue, vals[1].SValue)
+			if err != nil {
+				return nil, err
+			}
+
+			k, err := integerArg(vals[2].SValue, "rank")
+			if err != nil {
+				return nil, err
+			}
+
+			m, err := matrix.RandomIntegerRref(e.rng, r, c, k)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(m), nil
+		},
+	},
+
 	"ref": function{
 		[]VarType{MVar},
 		[]string{"mat"},
-		func(vals []*Value) (*Value, error) {
-			return valueFromMatrix(matrix.Ref(vals[0].MValue)), nil
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				m, err := matrix.RefMod(vals[0].MValue, e.modulus)
+				if err != nil {
+					return nil, err
+				}
+
+				return valueFromMatrix(m), nil
+			}
+
+			return valueFromMatrix(matrix.Ref(vals[0].MValue)), nil
+		},
+	},
+
+	"rref": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				m, err := matrix.RrefMod(vals[0].MValue, e.modulus)
+				if err != nil {
+					return nil, err
+				}
+
+				return valueFromMatrix(m), nil
+			}
+
+			return valueFromMatrix(matrix.Rref(vals[0].MValue)), nil
+		},
+	},
+
+	"invert": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				m, err := matrix.InverseMod(vals[0].MValue, e.modulus)
+				if err != nil {
+					return nil, err
+				}
+
+				return valueFromMatrix(m), nil
+			}
+
+			m, err := matrix.Inverse(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(m), nil
+		},
+	},
+
+	"invmod": function{
+		[]VarType{MVar, SVar},
+		[]string{"mat", "n"},
+		func(e *E, vals []*Value) (*Value, error) {
+			n, err := integerArg(vals[1].SValue, "modulus")
+			if err != nil {
+				return nil, err
+			}
+
+			m, err := matrix.InverseModN(vals[0].MValue, n)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(m), nil
+		},
+	},
+
+	"det": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			var det matrix.Frac
+			var err error
+
+			if e.modulus != 0 {
+				det, err = matrix.DeterminantMod(vals[0].MValue, e.modulus)
+			} else {
+				det, err = matrix.Determinant(vals[0].MValue)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: SVar, SValue: det}, nil
+		},
+	},
+
+	"null": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				m, err := matrix.NullSpaceMod(vals[0].MValue, e.modulus)
+				if err != nil {
+					return nil, err
+				}
+
+				return valueFromMatrix(m), nil
+			}
+
+			return valueFromMatrix(matrix.NullSpace(vals[0].MValue)), nil
+		},
+	},
+
+	"pinv": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				return nil, fmt.Errorf("the pseudoinverse is not defined modulo a prime")
+			}
+
+			return valueFromMatrix(matrix.PseudoInverse(vals[0].MValue)), nil
+		},
+	},
+
+	"ldl": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				return nil, fmt.Errorf("the LDLᵀ factorization is not defined modulo a prime")
+			}
+
+			l, d, err := matrix.LDL(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: MVar, MValue: l, Notes: []Note{{Label: "D:", Value: valueFromMatrix(d)}}}, nil
+		},
+	},
+
+	"chol": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				return nil, fmt.Errorf("the Cholesky factorization is not defined modulo a prime")
+			}
+
+			l, err := matrix.Cholesky(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			rational := matrix.New(l.Rows(), l.Cols())
+			for r := 1; r <= l.Rows(); r++ {
+				for c := 1; c <= l.Cols(); c++ {
+					if !l.Get(r, c).IsRational() {
+						return &Value{VType: RadMVar, RadMValue: l}, nil
+					}
+
+					rational.Set(r, c, l.Get(r, c).Coeff())
+				}
+			}
+
+			return valueFromMatrix(rational), nil
+		},
+	},
+
+	"qform": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				return nil, fmt.Errorf("quadratic forms cannot be classified modulo a prime")
+			}
+
+			m := vals[0].MValue
+
+			p, d, err := matrix.CongruenceDiagonalize(m)
+			if err != nil {
+				return nil, err
+			}
+
+			class, _ := matrix.ClassifyForm(m)
+			q, _ := matrix.Inverse(p) // congruence transformations are always invertible
+
+			minors := []string{}
+			for _, f := range matrix.LeadingMinors(m) {
+				minors = append(minors, f.String())
+			}
+
+			notes := []Note{
+				{Label: fmt.Sprintf("The quadratic form is %v", class)},
+				{Label: "Leading principal minors: " + strings.Join(minors, ", ")},
+				{Label: fmt.Sprintf("Substituting y = Qx with Q below gives %s:", diagonalForm(d)), Value: valueFromMatrix(q)},
+			}
+
+			return &Value{VType: MVar, MValue: d, Notes: notes}, nil
+		},
+	},
+
+	"charpoly": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			p, err := matrix.CharPoly(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: PVar, PValue: p}, nil
+		},
+	},
+
+	"pow": function{
+		[]VarType{MVar, SVar},
+		[]string{"mat", "n"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if !vals[1].SValue.IsWhole() {
+				return nil, fmt.Errorf("power must be an integer")
+			}
+
+			m, k := vals[0].MValue, vals[1].SValue.Reduce().Integer()
+
+			// Modulo a prime, a negative power is a power of the inverse modulo the prime, which may exist
+			// when the rational inverse does not, and the other way around.
+			if e.modulus != 0 && k < 0 {
+				inv, err := matrix.InverseMod(m, e.modulus)
+				if err != nil {
+					return nil, err
+				}
+
+				m, k = inv, -k
+			}
+
+			m, err := matrix.Power(m, k)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(m), nil
+		},
+	},
+
+	"minpoly": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				return nil, fmt.Errorf("the minimal polynomial cannot be computed modulo a prime")
+			}
+
+			p, err := matrix.MinPoly(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: PVar, PValue: p}, nil
+		},
+	},
+
+	"polyval": function{
+		[]VarType{SVar, MVar},
+		[]string{"p", "mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			// A number is a constant polynomial, so it evaluates to that multiple of the identity.
+			m, err := toPolynomial(vals[0]).PValue.EvalMatrix(vals[1].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(m), nil
+		},
+	},
+
+	"cayley": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			p, err := matrix.CharPoly(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			m, _ := p.EvalMatrix(vals[0].MValue)
+
+			label := fmt.Sprintf("The characteristic polynomial %v evaluated at the matrix is zero, as the Cayley-Hamilton theorem states", p)
+			if !m.Equals(matrix.New(m.Rows(), m.Cols())) {
+				label = fmt.Sprintf("The characteristic polynomial %v evaluated at the matrix is not zero", p)
+			}
+
+			return &Value{VType: MVar, MValue: m, Notes: []Note{{Label: label}}}, nil
+		},
+	},
+
+	"rowequiv": function{
+		[]VarType{MVar, MVar},
+		[]string{"a", "b"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				return nil, fmt.Errorf("row equivalence cannot be tested modulo a prime")
+			}
+
+			m, ok := matrix.RowEquivalent(vals[0].MValue, vals[1].MValue)
+			if !ok {
+				return &Value{
+					VType:  SVar,
+					SValue: matrix.NewScalarFrac(0),
+					Notes:  []Note{{Label: "The matrices are not row equivalent, since their reduced row echelon forms differ"}},
+				}, nil
+			}
+
+			return &Value{
+				VType:  SVar,
+				SValue: matrix.NewScalarFrac(1),
+				Notes:  []Note{{Label: "The matrices are row equivalent, with EA = B for E:", Value: valueFromMatrix(m)}},
+			}, nil
+		},
+	},
+
+	"similar": function{
+		[]VarType{MVar, MVar},
+		[]string{"a", "b"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				return nil, fmt.Errorf("similarity cannot be tested modulo a prime")
+			}
+
+			a, b := vals[0].MValue, vals[1].MValue
+
+			similar, err := matrix.Similar(a, b)
+			if err != nil {
+				return nil, err
+			}
+
+			fa, _ := matrix.InvariantFactors(a)
+			fb, _ := matrix.InvariantFactors(b)
+
+			notes := []Note{
+				{Label: "Invariant factors of A: " + joinPolys(fa)},
+				{Label: "Invariant factors of B: " + joinPolys(fb)},
+			}
+
+			if !similar {
+				notes = append(notes, Note{Label: "The matrices are not similar"})
+				return &Value{VType: SVar, SValue: matrix.NewScalarFrac(0), Notes: notes}, nil
+			}
+
+			if p, ok := matrix.SimilarityTransform(a, b); ok {
+				notes = append(notes, Note{Label: "The matrices are similar, with P⁻¹AP = B for P:", Value: valueFromMatrix(p)})
+			} else {
+				notes = append(notes, Note{Label: "The matrices are similar, but no transition matrix was found"})
+			}
+
+			return &Value{VType: SVar, SValue: matrix.NewScalarFrac(1), Notes: notes}, nil
+		},
+	},
+
+	"frobenius": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				return nil, fmt.Errorf("the rational canonical form cannot be computed modulo a prime")
+			}
+
+			f, p, err := matrix.Frobenius(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			factors, _ := matrix.InvariantFactors(vals[0].MValue)
+
+			return &Value{
+				VType:  MVar,
+				MValue: f,
+				Notes: []Note{
+					{Label: "Invariant factors: " + joinPolys(factors)},
+					{Label: "P⁻¹AP = F for P:", Value: valueFromMatrix(p)},
+				},
+			}, nil
+		},
+	},
+
+	"elemfactor": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				return nil, fmt.Errorf("elementary factorizations cannot be computed modulo a prime")
+			}
+
+			m := vals[0].MValue
+
+			ops, err := matrix.ElementaryFactors(m)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(ops) == 0 {
+				return &Value{VType: MVar, MValue: m, Notes: []Note{{Label: "The matrix is the identity, an empty product of elementary matrices"}}}, nil
+			}
+
+			strs := []string{}
+			for _, op := range ops {
+				strs = append(strs, op.String())
+			}
+
+			header := fmt.Sprintf("The matrix is the product E1⋯E%d of the elementary matrices undoing them, in order:", len(ops))
+			if len(ops) == 1 {
+				header = "The matrix is E1, the elementary matrix undoing it:"
+			}
+
+			notes := []Note{
+				{Label: "Row operations reducing the matrix to I: " + strings.Join(strs, ", ")},
+				{Label: header},
+			}
+
+			product := matrix.Identity(m.Rows())
+			for i, op := range ops {
+				elem := op.Inverse().Elementary(m.Rows())
+				product, _ = matrix.Multiply(product, elem)
+
+				notes = append(notes, Note{Label: fmt.Sprintf("E%d (%v):", i+1, op.Inverse()), Value: valueFromMatrix(elem)})
+			}
+
+			return &Value{VType: MVar, MValue: product, Notes: notes}, nil
+		},
+	},
+
+	"simplex": function{
+		[]VarType{MVar, MVar, MVar},
+		[]string{"c", "a", "b"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				return nil, fmt.Errorf("linear programs cannot be solved modulo a prime")
+			}
+
+			c, err := matrix.VectorEntries(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			b, err := matrix.VectorEntries(vals[2].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			sol, err := matrix.Simplex(c, vals[1].MValue, b)
+			if err != nil {
+				return nil, err
+			}
+
+			if sol.Status != matrix.Optimal {
+				return nil, fmt.Errorf("the linear program is %v", sol.Status)
+			}
+
+			return &Value{VType: MVar, MValue: sol.X, Notes: []Note{{Label: fmt.Sprintf("Maximum value: %v", sol.Value)}}}, nil
+		},
+	},
+
+	"steady": function{
+		[]VarType{MVar},
+		[]string{"p"},
+		func(e *E, vals []*Value) (*Value, error) {
+			p, byRows, err := transitionArg(e, vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			pi, err := matrix.SteadyState(p)
+			if err != nil {
+				return nil, err
+			}
+
+			if !byRows {
+				pi = matrix.Transpose(pi)
+			}
+
+			return valueFromMatrix(pi), nil
+		},
+	},
+
+	"absorb": function{
+		[]VarType{MVar},
+		[]string{"p"},
+		func(e *E, vals []*Value) (*Value, error) {
+			p, byRows, err := transitionArg(e, vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			chain, err := matrix.Absorb(p)
+			if err != nil {
+				return nil, err
+			}
+
+			n, t, b := chain.Fundamental, chain.Steps, chain.Probabilities
+			layout := "rows are transient states, columns absorbing states"
+			if !byRows {
+				n, t, b = matrix.Transpose(n), matrix.Transpose(t), matrix.Transpose(b)
+				layout = "columns are transient states, rows absorbing states"
+			}
+
+			notes := []Note{
+				{Label: fmt.Sprintf("Transient states: %s; absorbing states: %s", joinInts(chain.Transient), joinInts(chain.Absorbing))},
+				{Label: "Expected steps before absorption:", Value: valueFromMatrix(t)},
+				{Label: fmt.Sprintf("Absorption probabilities (%s):", layout), Value: valueFromMatrix(b)},
+			}
+
+			return &Value{VType: MVar, MValue: n, Notes: notes}, nil
+		},
+	},
+
+	"markov": function{
+		[]VarType{MVar, MVar, SVar},
+		[]string{"p", "x", "n"},
+		func(e *E, vals []*Value) (*Value, error) {
+			p, byRows, err := transitionArg(e, vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			if !vals[2].SValue.IsWhole() || vals[2].SValue.Numerator() < 0 {
+				return nil, fmt.Errorf("number of steps must be a non-negative integer")
+			}
+
+			x := vals[1].MValue
+			if !byRows {
+				x = matrix.Transpose(x)
+			}
+
+			if x.Rows() != 1 || x.Cols() != p.Rows() {
+				if byRows {
+					return nil, fmt.Errorf("the distribution must be a row vector with %d entries", p.Rows())
+				}
+
+				return nil, fmt.Errorf("the distribution must be a column vector with %d entries", p.Rows())
+			}
+
+			x, err = matrix.Distribution(p, x, vals[2].SValue.Reduce().Integer())
+			if err != nil {
+				return nil, err
+			}
+
+			if !byRows {
+				x = matrix.Transpose(x)
+			}
+
+			return valueFromMatrix(x), nil
+		},
+	},
+
+	"incidence": function{
+		[]VarType{MVar},
+		[]string{"adj"},
+		func(e *E, vals []*Value) (*Value, error) {
+			m, edges, err := matrix.Incidence(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			sep := "-"
+			if matrix.IsDirected(vals[0].MValue) {
+				sep = "->"
+			}
+
+			strs := []string{}
+			for _, edge := range edges {
+				strs = append(strs, fmt.Sprintf("%d%s%d", edge.From, sep, edge.To))
+			}
+
+			return &Value{VType: MVar, MValue: m, Notes: []Note{{Label: "Columns are the edges " + strings.Join(strs, ", ")}}}, nil
+		},
+	},
+
+	"laplacian": function{
+		[]VarType{MVar},
+		[]string{"adj"},
+		func(e *E, vals []*Value) (*Value, error) {
+			m, err := matrix.Laplacian(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(m), nil
+		},
+	},
+
+	"walks": function{
+		[]VarType{MVar, SVar},
+		[]string{"adj", "k"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if _, err := matrix.Edges(vals[0].MValue); err != nil {
+				return nil, err
+			}
+
+			if !vals[1].SValue.IsWhole() || vals[1].SValue.Numerator() < 0 {
+				return nil, fmt.Errorf("walk length must be a non-negative integer")
+			}
+
+			k := vals[1].SValue.Reduce().Integer()
+
+			m, err := matrix.Power(vals[0].MValue, k)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: MVar, MValue: m, Notes: []Note{{Label: fmt.Sprintf("Entry i,j counts the walks of length %d from vertex i to vertex j", k)}}}, nil
+		},
+	},
+
+	"trees": function{
+		[]VarType{MVar},
+		[]string{"adj"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				return nil, fmt.Errorf("spanning trees cannot be counted modulo a prime")
+			}
+
+			n, err := matrix.SpanningTrees(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: SVar, SValue: n}, nil
+		},
+	},
+
+	"recur": function{
+		[]VarType{MVar, MVar, SVar},
+		[]string{"coeffs", "initial", "n"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				return nil, fmt.Errorf("recurrences cannot be solved modulo a prime")
+			}
+
+			coeffs, err := matrix.VectorEntries(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			initial, err := matrix.VectorEntries(vals[1].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			rec, err := matrix.NewRecurrence(coeffs, initial)
+			if err != nil {
+				return nil, err
+			}
+
+			n, err := integerArg(vals[2].SValue, "term number")
+			if err != nil {
+				return nil, err
+			}
+
+			a, p, err := rec.Term(n)
+			if err != nil {
+				return nil, err
+			}
+
+			closed, err := rec.ClosedForm()
+			if err != nil {
+				closed = "No closed form: " + err.Error()
+			}
+
+			notes := []Note{
+				{Label: fmt.Sprintf("The matrix of the recurrence to the power %d, whose first row times the initial values gives a_%d:", n, n), Value: valueFromMatrix(p)},
+				{Label: "Characteristic polynomial: " + rec.CharPoly().String()},
+				{Label: closed},
+			}
+
+			return &Value{VType: SVar, SValue: a, Notes: notes}, nil
+		},
+	},
+
+	"ode": function{
+		[]VarType{MVar, MVar},
+		[]string{"A", "x0"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if e.modulus != 0 {
+				return nil, fmt.Errorf("differential equations cannot be solved modulo a prime")
+			}
+
+			a := vals[0].MValue
+
+			x, err := matrix.SolveODE(a, vals[1].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			notes := []Note{{Label: "Solution x(t) of x' = Ax with x(0) = x0"}}
+
+			cp, _ := matrix.CharPoly(a)
+			roots, mults, _ := cp.SplitRationalRoots()
+			for i, r := range roots {
+				shifted, _ := matrix.Add(a, matrix.Scale(r.Neg(), matrix.Identity(a.Rows())))
+				eigenvectors := matrix.NullSpace(shifted)
+
+				label := fmt.Sprintf("Eigenvalue %v with algebraic multiplicity %d and geometric multiplicity %d", r, mults[i], eigenvectors.Cols())
+				if eigenvectors.Cols() < mults[i] {
+					label += " is defective, so its Jordan chains give terms with powers of t"
+				}
+				label += ". Eigenvectors:"
+
+				notes = append(notes, Note{Label: label, Value: valueFromMatrix(eigenvectors)})
+			}
+
+			for _, pair := range x.Pairs {
+				label := fmt.Sprintf("Irrational eigenvalues %s, which give the exponentials with surds in their rates", pair.Eigenvalues())
+				if pair.Delta.Numerator() < 0 {
+					label = fmt.Sprintf("Complex eigenvalues %s, which give the cosine and sine terms", pair.Eigenvalues())
+				}
+
+				notes = append(notes, Note{Label: label})
+			}
+
+			return &Value{VType: ExpMVar, ExpMValue: x, Notes: notes}, nil
+		},
+	},
+
+	"hamming": function{
+		[]VarType{SVar},
+		[]string{"r"},
+		func(e *E, vals []*Value) (*Value, error) {
+			r, err := integerArg(vals[0].SValue, "number of parity bits")
+			if err != nil {
+				return nil, err
+			}
+
+			if r > 10 {
+				return nil, fmt.Errorf("Hamming codes are limited to 10 parity bits")
+			}
+
+			g, h, err := matrix.Hamming(r)
+			if err != nil {
+				return nil, err
+			}
+
+			notes := []Note{
+				{Label: fmt.Sprintf("Generator of the [%d,%d] Hamming code; codewords are Gm modulo 2", g.Rows(), g.Cols())},
+				{Label: "Parity-check matrix H:", Value: valueFromMatrix(h)},
+			}
+
+			return &Value{VType: MVar, MValue: g, Notes: notes}, nil
+		},
+	},
+
+	"paritycheck": function{
+		[]VarType{MVar},
+		[]string{"G"},
+		func(e *E, vals []*Value) (*Value, error) {
+			h, err := matrix.ParityCheck(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(h), nil
+		},
+	},
+
+	"encode": function{
+		[]VarType{MVar, MVar},
+		[]string{"G", "msg"},
+		func(e *E, vals []*Value) (*Value, error) {
+			c, err := matrix.Encode(vals[0].MValue, vals[1].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: MVar, MValue: c, Notes: []Note{{Label: "Codeword: " + matrix.FormatBits(c)}}}, nil
+		},
+	},
+
+	"syndrome": function{
+		[]VarType{MVar, MVar},
+		[]string{"H", "word"},
+		func(e *E, vals []*Value) (*Value, error) {
+			s, err := matrix.Syndrome(vals[0].MValue, vals[1].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: MVar, MValue: s, Notes: []Note{{Label: "Syndrome: " + matrix.FormatBits(s)}}}, nil
+		},
+	},
+
+	"correct": function{
+		[]VarType{MVar, MVar},
+		[]string{"H", "word"},
+		func(e *E, vals []*Value) (*Value, error) {
+			w, pos, err := matrix.CorrectError(vals[0].MValue, vals[1].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			label := "The word is a codeword: " + matrix.FormatBits(w)
+			if pos != 0 {
+				label = fmt.Sprintf("Corrected bit %d: %s", pos, matrix.FormatBits(w))
+			}
+
+			return &Value{VType: MVar, MValue: w, Notes: []Note{{Label: label}}}, nil
+		},
+	},
+
+	"interp": function{
+		[]VarType{MVar},
+		[]string{"points"},
+		func(e *E, vals []*Value) (*Value, error) {
+			xs, ys, err := pointsArg(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			p, err := matrix.Interpolate(xs, ys)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: PVar, PValue: p}, nil
+		},
+	},
+
+	"polyfit": function{
+		[]VarType{MVar, SVar},
+		[]string{"points", "degree"},
+		func(e *E, vals []*Value) (*Value, error) {
+			xs, ys, err := pointsArg(vals[0].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			degree, err := integerArg(vals[1].SValue, "degree")
+			if err != nil {
+				return nil, err
+			}
+
+			p, sse, err := matrix.PolyFit(xs, ys, degree)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: PVar, PValue: p, Notes: []Note{{Label: fmt.Sprintf("Sum of squared residuals: %v", sse)}}}, nil
+		},
+	},
+
+	"augment": function{
+		[]VarType{MVar, MVar},
+		[]string{"a", "b"},
+		func(e *E, vals []*Value) (*Value, error) {
+			m, err := matrix.Augment(vals[0].MValue, vals[1].MValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(m), nil
+		},
+	},
+
+	"stack": function{
+		[]VarType{MVar, MVar},
+		[]string{"a", "b"},
+		func(e *E, vals []*Value) (*Value, error) {
+			m := vals[0].MValue
+			for i, val := range vals[1:] {
+				var err error
+				if m, err = matrix.Stack(m, val.MValue); err != nil {
+					return nil, fmt.Errorf("matrix %d has %d columns, but the matrices above it have %d", i+2, val.MValue.Cols(), m.Cols())
+				}
+			}
+
+			return valueFromMatrix(m), nil
+		},
+	},
+
+	"block": function{
+		[]VarType{MVar},
+		[]string{"blocks"},
+		func(e *E, vals []*Value) (*Value, error) {
+			// The block literal [A, B; C, D] has already been assembled during evaluation.
+			return vals[0], nil
+		},
+	},
+
+	"blkdiag": function{
+		[]VarType{MVar},
+		[]string{"a"},
+		func(e *E, vals []*Value) (*Value, error) {
+			blocks := []matrix.M{}
+			for _, val := range vals {
+				blocks = append(blocks, val.MValue)
+			}
+
+			return valueFromMatrix(matrix.BlockDiagonal(blocks...)), nil
+		},
+	},
+
+	"submatrix": function{
+		[]VarType{MVar, SVar, SVar, SVar, SVar},
+		[]string{"mat", "r1", "r2", "c1", "c2"},
+		func(e *E, vals []*Value) (*Value, error) {
+			bounds := []int{}
+			for _, val := range vals[1:] {
+				if !val.SValue.IsWhole() {
+					return nil, fmt.Errorf("row and column numbers must be integers")
+				}
+
+				bounds = append(bounds, val.SValue.Reduce().Integer())
+			}
+
+			m, err := matrix.Submatrix(vals[0].MValue, bounds[0], bounds[1], bounds[2], bounds[3])
+			if err != nil {
+				return nil, err
+			}
+
+			return valueFromMatrix(m), nil
+		},
+	},
+
+	"partition": function{
+		[]VarType{MVar, MVar, MVar},
+		[]string{"mat", "rows", "cols"},
+		func(e *E, vals []*Value) (*Value, error) {
+			m := vals[0].MValue
+
+			rows, err := sizesArg(vals[1].MValue, "block heights")
+			if err != nil {
+				return nil, err
+			}
+
+			cols, err := sizesArg(vals[2].MValue, "block widths")
+			if err != nil {
+				return nil, err
+			}
+
+			blocks, err := matrix.Partition(m, rows, cols)
+			if err != nil {
+				return nil, err
+			}
+
+			notes := []Note{}
+			for i, row := range blocks {
+				for j, b := range row {
+					notes = append(notes, Note{Label: fmt.Sprintf("Block (%d,%d):", i+1, j+1), Value: valueFromMatrix(b)})
+				}
+			}
+
+			return &Value{VType: MVar, MValue: m, Notes: notes}, nil
+		},
+	},
+
+	"frac": function{
+		[]VarType{MVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			return vals[0], nil
+		},
+	},
+
+	"sfrac": function{
+		[]VarType{SVar},
+		[]string{"scal"},
+		func(e *E, vals []*Value) (*Value, error) {
+			return vals[0], nil
+		},
+	},
+}
+
+// variadicFunctions lists the functions whose last argument may be repeated any number of times.
+var variadicFunctions = map[string]bool{
+	"stack":   true,
+	"blkdiag": true,
+}
+
+// sizesArg converts a vector argument to a list of integers, such as the sizes of the blocks of a partition.
+func sizesArg(m matrix.M, name string) ([]int, error) {
+	v, err := matrix.VectorEntries(m)
+	if err != nil {
+		return nil, fmt.Errorf("the %s must be a vector: %v", name, err)
+	}
+
+	sizes := make([]int, len(v))
+	for i, f := range v {
+		if sizes[i], err = integerArg(f, "each of the "+name); err != nil {
+			return nil, err
+		}
+	}
+
+	return sizes, nil
+}
+
+// sizeArg converts a scalar argument to a matrix size.
+func sizeArg(f matrix.Frac) (int, error) {
+	if !f.IsWhole() {
+		return 0, fmt.Errorf("size must be an integer")
+	}
+
+	n := f.Reduce().Integer()
+	if n < 0 {
+		return 0, fmt.Errorf("size must be positive")
+	}
+
+	return n, nil
+}
+
+// sizeArgs converts two scalar arguments to the number of rows and columns of a matrix.
+func sizeArgs(rows, cols matrix.Frac) (int, int, error) {
+	r, err := sizeArg(rows)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	c, err := sizeArg(cols)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return r, c, nil
+}
+
+// integerArg converts a scalar argument to an integer, naming the argument if it is not one.
+func integerArg(f matrix.Frac, name string) (int, error) {
+	if !f.IsWhole() {
+		return 0, fmt.Errorf("%s must be an integer", name)
+	}
+
+	return f.Reduce().Integer(), nil
+}
+
+// pointsArg splits a matrix with a row for each point into the x and y values of the points.
+func pointsArg(m matrix.M) ([]matrix.Frac, []matrix.Frac, error) {
+	if m.Cols() != 2 {
+		return nil, nil, fmt.Errorf("points must be given as the rows of a matrix with 2 columns, x and y")
+	}
+
+	xs, ys := []matrix.Frac{}, []matrix.Frac{}
+	for r := 1; r <= m.Rows(); r++ {
+		xs = append(xs, m.Get(r, 1))
+		ys = append(ys, m.Get(r, 2))
+	}
+
+	return xs, ys, nil
+}
+
+// elementaryArgs reads the size and the row numbers passed to an elementary matrix builder.
+func elementaryArgs(vals []*Value) (int, []int, error) {
+	n, err := sizeArg(vals[0].SValue)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	rows := []int{}
+	for _, val := range vals[1:] {
+		if !val.SValue.IsWhole() {
+			return 0, nil, fmt.Errorf("row numbers must be integers")
+		}
+
+		r := val.SValue.Reduce().Integer()
+		if r < 1 || r > n {
+			return 0, nil, fmt.Errorf("row %d does not exist in a matrix of size %d", r, n)
+		}
+
+		rows = append(rows, r)
+	}
+
+	return n, rows, nil
+}
+
+// elementaryValue returns the elementary matrix of size n for the row operation, noting the operation.
+func elementaryValue(n int, op matrix.RowOp[matrix.Frac]) *Value {
+	return &Value{
+		VType:  MVar,
+		MValue: op.Elementary(n),
+		Notes:  []Note{{Label: fmt.Sprintf("Performs %v", op)}},
+	}
+}
+
+// transitionArg validates a Markov chain transition matrix argument and returns it with rows summing to one,
+// along with whether the rows of the original did.
+func transitionArg(e *E, p matrix.M) (matrix.M, bool, error) {
+	if e.modulus != 0 {
+		return p, false, fmt.Errorf("Markov chains cannot be analyzed modulo a prime")
+	}
+
+	byRows, err := matrix.Stochastic(p)
+	if err != nil {
+		return p, false, err
+	}
+
+	if !byRows {
+		p = matrix.Transpose(p)
+	}
+
+	return p, byRows, nil
+}
+
+// joinInts lists integers separated by commas.
+func joinInts(ints []int) string {
+	strs := []string{}
+	for _, i := range ints {
+		strs = append(strs, strconv.Itoa(i))
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+// floatFunctions are used in place of functions when any argument is floating-point.
+// Every argument is promoted to a floating-point value before the call.
+var floatFunctions = map[string]function{
+	"identity": function{
+		[]VarType{FVar},
+		[]string{"size"},
+		func(e *E, vals []*Value) (*Value, error) {
+			n := vals[0].FValue
+			if n != math.Trunc(n) {
+				return nil, fmt.Errorf("size must be an integer")
+			}
+
+			if n < 0 {
+				return nil, fmt.Errorf("size must be positive")
+			}
+
+			return &Value{VType: FMVar, FMValue: matrix.IdentityFM(int(n))}, nil
+		},
+	},
+
+	"ref": function{
+		[]VarType{FMVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			return &Value{VType: FMVar, FMValue: matrix.RefFM(vals[0].FMValue, e.tolerance)}, nil
 		},
 	},
 
 	"rref": function{
-		[]VarType{MVar},
+		[]VarType{FMVar},
 		[]string{"mat"},
-		func(vals []*Value) (*Value, error) {
-			return valueFromMatrix(matrix.Rref(vals[0].MValue)), nil
+		func(e *E, vals []*Value) (*Value, error) {
+			return &Value{VType: FMVar, FMValue: matrix.RrefFM(vals[0].FMValue, e.tolerance)}, nil
 		},
 	},
 
 	"invert": function{
-		[]VarType{MVar},
+		[]VarType{FMVar},
 		[]string{"mat"},
-		func(vals []*Value) (*Value, error) {
-			m, err := matrix.Inverse(vals[0].MValue)
+		func(e *E, vals []*Value) (*Value, error) {
+			m, err := matrix.InverseFM(vals[0].FMValue, e.tolerance)
 			if err != nil {
 				return nil, err
 			}
 
-			return valueFromMatrix(m), nil
+			return &Value{VType: FMVar, FMValue: m}, nil
+		},
+	},
+
+	"det": function{
+		[]VarType{FMVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			det, err := matrix.DeterminantFM(vals[0].FMValue, e.tolerance)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: FVar, FValue: det}, nil
+		},
+	},
+
+	"null": function{
+		[]VarType{FMVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			return &Value{VType: FMVar, FMValue: matrix.NullSpaceFM(vals[0].FMValue, e.tolerance)}, nil
 		},
 	},
 
 	"augment": function{
-		[]VarType{MVar, MVar},
+		[]VarType{FMVar, FMVar},
 		[]string{"a", "b"},
-		func(vals []*Value) (*Value, error) {
-			m, err := matrix.Augment(vals[0].MValue, vals[1].MValue)
+		func(e *E, vals []*Value) (*Value, error) {
+			m, err := matrix.AugmentFM(vals[0].FMValue, vals[1].FMValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: FMVar, FMValue: m}, nil
+		},
+	},
+
+	"frac": function{
+		[]VarType{FMVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			return valueFromMatrix(matrix.NearestMatrix(vals[0].FMValue, fracTolerance(e))), nil
+		},
+	},
+
+	"sfrac": function{
+		[]VarType{FVar},
+		[]string{"scal"},
+		func(e *E, vals []*Value) (*Value, error) {
+			return &Value{VType: SVar, SValue: matrix.NearestFrac(vals[0].FValue, fracTolerance(e))}, nil
+		},
+	},
+}
+
+// fracTolerance returns how far a floating-point value may be from the fraction it is converted back to.
+// Values are matched to the displayed precision, so that a result shown as 0.333333 becomes 1/3.
+func fracTolerance(e *E) float64 {
+	return math.Pow(10, -float64(e.precision)) / 2
+}
+
+// polyFunctions are used in place of functions when any argument is a polynomial or polynomial matrix.
+// Every argument is promoted to a polynomial value before the call.
+var polyFunctions = map[string]function{
+	"polyval": function{
+		[]VarType{PVar, PMVar},
+		[]string{"p", "mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			a, ok := constantMatrix(vals[1].PMValue)
+			if !ok {
+				return nil, fmt.Errorf("polynomials can only be evaluated at matrices of numbers")
+			}
+
+			m, err := vals[0].PValue.EvalMatrix(a)
 			if err != nil {
 				return nil, err
 			}
@@ -73,6 +1527,157 @@ var functions = map[string]function{
 			return valueFromMatrix(m), nil
 		},
 	},
+
+	"companion": function{
+		[]VarType{PVar},
+		[]string{"p"},
+		func(e *E, vals []*Value) (*Value, error) {
+			if vals[0].PValue.Degree() < 1 {
+				return nil, fmt.Errorf("only polynomials of degree 1 or more have a companion matrix")
+			}
+
+			return valueFromMatrix(matrix.Companion(vals[0].PValue)), nil
+		},
+	},
+
+	"det": function{
+		[]VarType{PMVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			det, err := matrix.DeterminantPM(vals[0].PMValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: PVar, PValue: det}, nil
+		},
+	},
+}
+
+// symbolicFunctions are used in place of functions when any argument depends on a symbolic parameter.
+// Every argument is promoted to a symbolic value before the call.
+var symbolicFunctions = map[string]function{
+	"rref": function{
+		[]VarType{RMVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			m, splits := matrix.RrefRM(vals[0].RMValue)
+
+			return &Value{
+				VType:   RMVar,
+				RMValue: m,
+				Notes: splitNotes(vals[0].RMValue, splits, func(m matrix.M) (*Value, error) {
+					return valueFromMatrix(matrix.Rref(m)), nil
+				}),
+			}, nil
+		},
+	},
+
+	"augment": function{
+		[]VarType{RMVar, RMVar},
+		[]string{"a", "b"},
+		func(e *E, vals []*Value) (*Value, error) {
+			m, err := matrix.AugmentRM(vals[0].RMValue, vals[1].RMValue)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Value{VType: RMVar, RMValue: m}, nil
+		},
+	},
+
+	"invert": function{
+		[]VarType{RMVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			m, err := matrix.InverseRM(vals[0].RMValue)
+			if err != nil {
+				return nil, err
+			}
+
+			det, _ := matrix.DeterminantRM(vals[0].RMValue)
+
+			notes := []Note{}
+			for _, s := range matrix.ZerosRM(det) {
+				notes = append(notes, Note{Label: fmt.Sprintf("The matrix has no inverse when %v", s)})
+			}
+
+			return &Value{VType: RMVar, RMValue: m, Notes: notes}, nil
+		},
+	},
+
+	"det": function{
+		[]VarType{RMVar},
+		[]string{"mat"},
+		func(e *E, vals []*Value) (*Value, error) {
+			det, err := matrix.DeterminantRM(vals[0].RMValue)
+			if err != nil {
+				return nil, err
+			}
+
+			notes := []Note{}
+			for _, s := range matrix.ZerosRM(det) {
+				notes = append(notes, Note{Label: fmt.Sprintf("The determinant vanishes when %v", s)})
+			}
+
+			return &Value{VType: RVar, RValue: det, Notes: notes}, nil
+		},
+	},
+}
+
+// joinPolys lists polynomials separated by commas, or "none" if there are none.
+func joinPolys(polys []matrix.Poly) string {
+	if len(polys) == 0 {
+		return "none"
+	}
+
+	strs := []string{}
+	for _, p := range polys {
+		strs = append(strs, p.String())
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+// diagonalForm writes out the quadratic form of a diagonal matrix in the variables y1, y2, ..., such as "4y1² - y2²".
+func diagonalForm(d matrix.M) string {
+	s := ""
+
+	for k := 1; k <= d.Rows(); k++ {
+		f := d.Get(k, k)
+		if f.IsZero() {
+			continue
+		}
+
+		switch {
+		case s == "" && f.Numerator() < 0:
+			s = "-"
+		case s != "" && f.Numerator() < 0:
+			s += " - "
+		case s != "":
+			s += " + "
+		}
+
+		if f.Numerator() < 0 {
+			f = f.Neg()
+		}
+
+		switch {
+		case f.Equals(matrix.NewScalarFrac(1)):
+		case f.IsWhole():
+			s += f.String()
+		default:
+			s += "(" + f.String() + ")"
+		}
+
+		s += fmt.Sprintf("y%d²", k)
+	}
+
+	if s == "" {
+		return "0"
+	}
+
+	return s
 }
 
 func valueFromMatrix(m matrix.M) *Value {
@@ -83,12 +1688,24 @@ func valueFromMatrix(m matrix.M) *Value {
 }
 
 func checkFunctionArgs(vals []*Value, fname string, fn function) error {
-	if len(vals) != len(fn.signature) {
-		return fmt.Errorf("call to %s takes %d arguments, but was supplied %d", fname, len(fn.signature), len(vals))
+	signature := fn.signature
+
+	if variadicFunctions[fname] {
+		if len(vals) < len(signature) {
+			return fmt.Errorf("call to %s takes at least %d arguments, but was supplied %d", fname, len(signature), len(vals))
+		}
+
+		for len(signature) < len(vals) {
+			signature = append(signature[:len(signature):len(signature)], signature[len(signature)-1])
+		}
+	}
+
+	if len(vals) != len(signature) {
+		return fmt.Errorf("call to %s takes %d arguments, but was supplied %d", fname, len(signature), len(vals))
 	}
 
 	correct := true
-	for i, vtype := range fn.signature {
+	for i, vtype := range signature {
 		if vtype != vals[i].VType {
 			correct = false
 			break
@@ -96,7 +1713,7 @@ func checkFunctionArgs(vals []*Value, fname string, fn function) error {
 	}
 
 	if !correct {
-		return fmt.Errorf("call to %s expects arguments (%s) but was supplied (%s)", fname, vartypesToString(fn.signature), valuesVartypesToString(vals))
+		return fmt.Errorf("call to %s expects arguments (%s) but was supplied (%s)", fname, vartypesToString(signature), valuesVartypesToString(vals))
 	}
 
 	return nil
@@ -111,6 +1728,22 @@ func vartypesToString(vtypes []VarType) string {
 			s = "matrix"
 		case SVar:
 			s = "scalar"
+		case PMVar:
+			s = "polynomial matrix"
+		case PVar:
+			s = "polynomial"
+		case RMVar:
+			s = "symbolic matrix"
+		case RVar:
+			s = "symbolic scalar"
+		case FMVar:
+			s = "floating-point matrix"
+		case FVar:
+			s = "floating-point scalar"
+		case RadMVar:
+			s = "radical matrix"
+		case ExpMVar:
+			s = "exponential vector"
 		}
 
 		strs = append(strs, s)
@@ -128,6 +1761,22 @@ func valuesVartypesToString(vals []*Value) string {
 			s = "matrix"
 		case SVar:
 			s = "scalar"
+		case PMVar:
+			s = "polynomial matrix"
+		case PVar:
+			s = "polynomial"
+		case RMVar:
+			s = "symbolic matrix"
+		case RVar:
+			s = "symbolic scalar"
+		case FMVar:
+			s = "floating-point matrix"
+		case FVar:
+			s = "floating-point scalar"
+		case RadMVar:
+			s = "radical matrix"
+		case ExpMVar:
+			s = "exponential vector"
 		}
 
 		strs = append(strs, s)
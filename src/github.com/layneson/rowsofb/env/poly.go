@@ -0,0 +1,99 @@
+package env
+
+import (
+	"fmt"
+
+	"github.com/layneson/rowsofb/matrix"
+)
+
+// isPolynomial returns true if the value holds a polynomial or a polynomial matrix.
+func isPolynomial(val *Value) bool {
+	return val.VType == PVar || val.VType == PMVar
+}
+
+// toPolynomial promotes a scalar to a constant polynomial and a matrix to a polynomial matrix.
+// Values which are already polynomial are returned unchanged.
+func toPolynomial(val *Value) *Value {
+	switch val.VType {
+	case SVar:
+		return &Value{VType: PVar, PValue: matrix.NewScalarPoly(val.SValue)}
+	case MVar:
+		return &Value{VType: PMVar, PMValue: matrix.PMFromMatrix(val.MValue)}
+	}
+
+	return val
+}
+
+// constantMatrix converts a polynomial matrix with constant entries back to a matrix of fractions.
+// The bool return value is false if some entry is not constant.
+func constantMatrix(m matrix.PM) (matrix.M, bool) {
+	rm := matrix.New(m.Rows(), m.Cols())
+
+	for r := 1; r <= m.Rows(); r++ {
+		for c := 1; c <= m.Cols(); c++ {
+			if !m.Get(r, c).IsConstant() {
+				return rm, false
+			}
+
+			rm.Set(r, c, m.Get(r, c).Coeff(0))
+		}
+	}
+
+	return rm, true
+}
+
+func evalPolyAddition(subtraction bool, left, right *Value) (*Value, error) {
+	if left.VType == PVar {
+		if subtraction {
+			right.PValue = right.PValue.Neg()
+		}
+
+		return &Value{VType: PVar, PValue: left.PValue.Add(right.PValue)}, nil
+	}
+
+	if subtraction {
+		right.PMValue = matrix.ScalePM(matrix.NewScalarPoly(matrix.NewScalarFrac(-1)), right.PMValue)
+	}
+
+	sum, err := matrix.AddPM(left.PMValue, right.PMValue)
+	if err != nil {
+		return nil, fmt.Errorf("cannot perform addition or subtraction on two matrices of different sizes")
+	}
+
+	return &Value{VType: PMVar, PMValue: sum}, nil
+}
+
+func evalPolyMultiplication(division bool, left, right *Value) (*Value, error) {
+	if division {
+		if right.VType == PMVar {
+			return nil, fmt.Errorf("cannot divide by a matrix")
+		}
+
+		if right.PValue.IsZero() {
+			return nil, fmt.Errorf("cannot divide by zero")
+		}
+
+		if !right.PValue.IsConstant() {
+			return nil, fmt.Errorf("cannot divide by a non-constant polynomial")
+		}
+
+		right.PValue = matrix.NewScalarPoly(right.PValue.Lead().Reciprocal())
+	}
+
+	switch {
+	case left.VType == PVar && right.VType == PVar:
+		return &Value{VType: PVar, PValue: left.PValue.Mul(right.PValue)}, nil
+	case left.VType == PVar && right.VType == PMVar:
+		return &Value{VType: PMVar, PMValue: matrix.ScalePM(left.PValue, right.PMValue)}, nil
+	case left.VType == PMVar && right.VType == PVar:
+		return &Value{VType: PMVar, PMValue: matrix.ScalePM(right.PValue, left.PMValue)}, nil
+	}
+
+	if left.PMValue.Cols() != right.PMValue.Rows() {
+		return nil, fmt.Errorf("cannot multiply a %dx%d matrix by a %dx%d matrix", left.PMValue.Rows(), left.PMValue.Cols(), right.PMValue.Rows(), right.PMValue.Cols())
+	}
+
+	product, _ := matrix.MultiplyPM(left.PMValue, right.PMValue)
+
+	return &Value{VType: PMVar, PMValue: product}, nil
+}
@@ -0,0 +1,15 @@
+package env
+
+import "math/rand"
+
+// Seed returns the seed the random matrix functions were last reset with.
+func (e *E) Seed() int64 {
+	return e.seed
+}
+
+// SetSeed resets the source of the random matrix functions, so that the same sequence of calls
+// after the same seed produces the same matrices.
+func (e *E) SetSeed(seed int64) {
+	e.seed = seed
+	e.rng = rand.New(rand.NewSource(seed))
+}
@@ -0,0 +1,150 @@
+package env
+
+import (
+	"fmt"
+
+	"github.com/layneson/rowsofb/matrix"
+)
+
+// SetParam unbinds the given scalar variable, turning it into a symbolic parameter.
+// Expressions using the variable then evaluate to rational functions in it until it is assigned a value again.
+// It assumes the given rune is a valid scalar variable name.
+func (e *E) SetParam(v rune) {
+	e.SetVar(v, &Value{VType: RVar, RValue: matrix.NewParam(v)})
+}
+
+// isSymbolic returns true if the value holds a rational function or a matrix of rational functions.
+func isSymbolic(val *Value) bool {
+	return val.VType == RVar || val.VType == RMVar
+}
+
+// paramOf returns the parameter a symbolic value depends on, or zero if it has none.
+func paramOf(val *Value) rune {
+	switch val.VType {
+	case RVar:
+		return val.RValue.Param()
+	case RMVar:
+		return matrix.ParamRM(val.RMValue)
+	}
+
+	return 0
+}
+
+// checkSymbolic returns an error if the given values cannot be combined symbolically, either because
+// they depend on different parameters or because one of them is a polynomial in the indeterminate.
+func checkSymbolic(vals ...*Value) error {
+	var v rune
+
+	for _, val := range vals {
+		if isPolynomial(val) {
+			return fmt.Errorf("cannot combine polynomials with symbolic parameters")
+		}
+
+		p := paramOf(val)
+		if p == 0 {
+			continue
+		}
+
+		if v != 0 && p != v {
+			return fmt.Errorf("cannot combine parameters %c and %c", v, p)
+		}
+
+		v = p
+	}
+
+	return nil
+}
+
+// toSymbolic promotes a scalar to a constant rational function and a matrix to a matrix of rational functions.
+// Values which are already symbolic are returned unchanged.
+func toSymbolic(val *Value) *Value {
+	switch val.VType {
+	case SVar:
+		return &Value{VType: RVar, RValue: matrix.RatFuncFromFrac(val.SValue)}
+	case MVar:
+		return &Value{VType: RMVar, RMValue: matrix.RMFromMatrix(val.MValue)}
+	}
+
+	return val
+}
+
+func evalSymbolicAddition(subtraction bool, left, right *Value) (*Value, error) {
+	if left.VType == RVar {
+		if subtraction {
+			right.RValue = right.RValue.Neg()
+		}
+
+		return &Value{VType: RVar, RValue: left.RValue.Add(right.RValue)}, nil
+	}
+
+	if subtraction {
+		right.RMValue = matrix.ScaleRM(matrix.RatFuncFromFrac(matrix.NewScalarFrac(-1)), right.RMValue)
+	}
+
+	sum, err := matrix.AddRM(left.RMValue, right.RMValue)
+	if err != nil {
+		return nil, fmt.Errorf("cannot perform addition or subtraction on two matrices of different sizes")
+	}
+
+	return &Value{VType: RMVar, RMValue: sum}, nil
+}
+
+func evalSymbolicMultiplication(division bool, left, right *Value) (*Value, error) {
+	if division {
+		if right.VType == RMVar {
+			return nil, fmt.Errorf("cannot divide by a matrix")
+		}
+
+		if right.RValue.IsZero() {
+			return nil, fmt.Errorf("cannot divide by zero")
+		}
+
+		right.RValue = right.RValue.Reciprocal()
+	}
+
+	switch {
+	case left.VType == RVar && right.VType == RVar:
+		return &Value{VType: RVar, RValue: left.RValue.Mul(right.RValue)}, nil
+	case left.VType == RVar && right.VType == RMVar:
+		return &Value{VType: RMVar, RMValue: matrix.ScaleRM(left.RValue, right.RMValue)}, nil
+	case left.VType == RMVar && right.VType == RVar:
+		return &Value{VType: RMVar, RMValue: matrix.ScaleRM(right.RValue, left.RMValue)}, nil
+	}
+
+	if left.RMValue.Cols() != right.RMValue.Rows() {
+		return nil, fmt.Errorf("cannot multiply a %dx%d matrix by a %dx%d matrix", left.RMValue.Rows(), left.RMValue.Cols(), right.RMValue.Rows(), right.RMValue.Cols())
+	}
+
+	product, _ := matrix.MultiplyRM(left.RMValue, right.RMValue)
+
+	return &Value{VType: RMVar, RMValue: product}, nil
+}
+
+// splitNotes returns a note for each split, holding the result of f applied to the matrix with the parameter
+// fixed at the split's value. Splits without a rational value only describe their condition.
+func splitNotes(m matrix.RM, splits []matrix.Split, f func(matrix.M) (*Value, error)) []Note {
+	notes := []Note{}
+
+	for _, s := range splits {
+		if !s.Rational {
+			notes = append(notes, Note{Label: fmt.Sprintf("The result does not hold when %v", s)})
+			continue
+		}
+
+		special, err := matrix.EvalRM(m, s.Value)
+		if err != nil {
+			notes = append(notes, Note{Label: fmt.Sprintf("When %v, the matrix is undefined", s)})
+			continue
+		}
+
+		val, err := f(special)
+		if err != nil {
+			notes = append(notes, Note{Label: fmt.Sprintf("When %v, %v", s, err)})
+			continue
+		}
+
+		notes = append(notes, Note{Label: fmt.Sprintf("When %v:", s), Value: val})
+	}
+
+	return notes
+}
@@ -77,14 +77,22 @@ const (
 
 	TTArrow
 	TTComma
+	TTSemicolon
+	TTMod
 
 	// parenthesis
 	TTLParen
 	TTRParen
 
+	// brackets, which enclose a block matrix
+	TTLBracket
+	TTRBracket
+
 	// literals
 	TTNum
 	TTFunc
+	TTIndet  // the polynomial indeterminate x
+	TTString // text in double quotes; the literal holds the text without its quotes or escapes
 
 	// variables
 	TTMVar   // matrix variable
@@ -110,14 +118,26 @@ func (tt TokenType) String() string {
 		return "arrow"
 	case TTComma:
 		return "comma"
+	case TTSemicolon:
+		return "semicolon"
+	case TTMod:
+		return "mod"
 	case TTLParen:
 		return "lparen"
 	case TTRParen:
 		return "rparen"
+	case TTLBracket:
+		return "lbracket"
+	case TTRBracket:
+		return "rbracket"
 	case TTNum:
 		return "num"
 	case TTFunc:
 		return "func"
+	case TTIndet:
+		return "indet"
+	case TTString:
+		return "string"
 	case TTMVar:
 		return "mvar"
 	case TTSVar:
@@ -133,6 +153,14 @@ func (tt TokenType) String() string {
 	return "unknown"
 }
 
+// Indeterminate is the letter which stands for the polynomial indeterminate. It cannot be used as a scalar variable.
+const Indeterminate = 'x'
+
+// keywords maps reserved words to their token types. Any other word lexes as a function name.
+var keywords = map[string]TokenType{
+	"mod": TTMod,
+}
+
 // Lex takes a line of text and parses it into tokens.
 func Lex(data string) ([]*Token, error) {
 	toks := []*Token{}
@@ -176,10 +204,32 @@ func Lex(data string) ([]*Token, error) {
 			lex.peekInc()
 			toks = append(toks, lex.consume(TTRParen))
 			continue
+		case '[':
+			lex.peekInc()
+			toks = append(toks, lex.consume(TTLBracket))
+			continue
+		case ']':
+			lex.peekInc()
+			toks = append(toks, lex.consume(TTRBracket))
+			continue
 		case ',':
 			lex.peekInc()
 			toks = append(toks, lex.consume(TTComma))
 			continue
+		case ';':
+			lex.peekInc()
+			toks = append(toks, lex.consume(TTSemicolon))
+			continue
+		}
+
+		if lex.peek() == '"' {
+			tok, err := lexString(lex)
+			if err != nil {
+				return toks, err
+			}
+
+			toks = append(toks, tok)
+			continue
 		}
 
 		if matchNumber(lex) {
@@ -188,7 +238,12 @@ func Lex(data string) ([]*Token, error) {
 		}
 
 		if matchFunction(lex) {
-			toks = append(toks, lex.consume(TTFunc))
+			tok := lex.consume(TTFunc)
+			if keyword, ok := keywords[tok.Literal]; ok {
+				tok.TType = keyword
+			}
+
+			toks = append(toks, tok)
 			continue
 		} else {
 			lex.peekReset()
@@ -204,7 +259,7 @@ func Lex(data string) ([]*Token, error) {
 				continue
 			}
 
-			if runeMatchLowercase(lex.peek()) {
+			if runeMatchLowercase(lex.peek()) && lex.peek() != Indeterminate {
 				lex.peekInc()
 
 				toks = append(toks, lex.consume(TTDSVar))
@@ -228,6 +283,13 @@ func Lex(data string) ([]*Token, error) {
 			continue
 		}
 
+		if lex.peek() == Indeterminate {
+			lex.peekInc()
+
+			toks = append(toks, lex.consume(TTIndet))
+			continue
+		}
+
 		if runeMatchLowercase(lex.peek()) {
 			lex.peekInc()
 
@@ -296,3 +358,28 @@ func matchFunction(lex *lexer) bool {
 
 	return true
 }
+
+// lexString reads text in double quotes, in which \" stands for a quote and \\ for a backslash.
+// Everything else, whitespace included, is kept as it is.
+func lexString(lex *lexer) (*Token, error) {
+	lex.peekInc()
+
+	text := []rune{}
+	for {
+		r := lex.peekInc()
+
+		switch r {
+		case rune(0):
+			return nil, fmt.Errorf("text starting at %q is missing its closing quote", string(lex.data[lex.next:]))
+		case '"':
+			lex.consumeIgnore()
+			return &Token{TType: TTString, Literal: string(text)}, nil
+		case '\\':
+			if next := lex.peek(); next == '"' || next == '\\' {
+				r = lex.peekInc()
+			}
+		}
+
+		text = append(text, r)
+	}
+}
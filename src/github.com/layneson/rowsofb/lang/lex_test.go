@@ -12,6 +12,11 @@ func TestLex(t *testing.T) {
 		"cos(5) + $a*$Z - $$": []TokenType{TTFunc, TTLParen, TTNum, TTRParen, TTPlus, TTDSVar, TTMult, TTDMVar, TTMinus, TTDAMVar},
 		"5 - 7 -> A":          []TokenType{TTNum, TTMinus, TTNum, TTArrow, TTMVar},
 		"blub(5, 6, A)":       []TokenType{TTFunc, TTLParen, TTNum, TTComma, TTNum, TTComma, TTMVar, TTRParen},
+		"rref(A) mod 7 -> B":  []TokenType{TTFunc, TTLParen, TTMVar, TTRParen, TTMod, TTNum, TTArrow, TTMVar},
+		"det(A - x*I)":        []TokenType{TTFunc, TTLParen, TTMVar, TTMinus, TTIndet, TTMult, TTMVar, TTRParen},
+		"[A, B; C, 1]":        []TokenType{TTLBracket, TTMVar, TTComma, TTMVar, TTSemicolon, TTMVar, TTComma, TTNum, TTRBracket},
+		`encrypt K "a + b"`:   []TokenType{TTFunc, TTMVar, TTString, TTEOF},
+		`show("x", "")`:       []TokenType{TTFunc, TTLParen, TTString, TTComma, TTString, TTRParen, TTEOF},
 	}
 
 	for input, expected := range tmap {
@@ -33,3 +38,27 @@ func TestLex(t *testing.T) {
 		}
 	}
 }
+
+func TestLexString(t *testing.T) {
+	tmap := map[string]string{
+		"\"MEET  ME\tAT NOON\"": "MEET  ME\tAT NOON",
+		`"say \"hi\""`:          `say "hi"`,
+		`"back\\slash \n"`:      `back\slash \n`,
+		`""`:                    "",
+	}
+
+	for input, expected := range tmap {
+		output, err := Lex(input)
+		if err != nil {
+			t.Fatalf("lex test failed with error: %v", err)
+		}
+
+		if output[0].TType != TTString || output[0].Literal != expected {
+			t.Fatalf("lexing %s should give the string %q but gave %v %q", input, expected, output[0].TType, output[0].Literal)
+		}
+	}
+
+	if _, err := Lex(`"no closing quote`); err == nil {
+		t.Fatalf("lexing text without a closing quote must fail")
+	}
+}
@@ -8,12 +8,15 @@ import (
 /*
    Parsing grammar:
 
-       expr    -> term ((ttPlus | ttMinus) term)* (arrow (ttMVar | ttSVar))? EOF
+       expr    -> term ((ttPlus | ttMinus) term)* (ttMod ttNum)? (arrow (ttMVar | ttSVar))? EOF
        term    -> factor ((ttMult | ttDiv) factor)*
        factor  -> (ttMinus)? ttNum
+               -> (ttMinus)? ttIndet
                -> (ttMinus)? ttFunc ttLParen expr (ttComma expr)* ttRParen
                -> (ttMinus)? ttDMVar | ttDSVar | ttDAMVar | ttMVar | ttSVar
                -> (ttMinus)? ttLParen expr ttRParen
+               -> (ttMinus)? ttLBracket row (ttSemicolon row)* ttRBracket
+       row     -> expr (ttComma expr)*
 */
 
 // A ExprNode represents an expression.
@@ -23,6 +26,8 @@ type ExprNode struct {
 	Operators []*Token
 	Terms     []*TermNode
 
+	Modulus *Token // nil if the expression is not evaluated modulo a number
+
 	ResultVar *Token
 }
 
@@ -32,6 +37,10 @@ func (enode *ExprNode) String() string {
 		s += fmt.Sprintf(" <%s> %s", op.TType, enode.Terms[i])
 	}
 
+	if enode.Modulus != nil {
+		s += fmt.Sprintf(" <%s> <%s>", TTMod, enode.Modulus.TType)
+	}
+
 	return s + ")"
 }
 
@@ -58,21 +67,30 @@ type FactorType int
 // FactorType definitions.
 const (
 	NumFactor FactorType = iota
+	IndetFactor
 	FuncFactor
 	VarFactor
 	ParenFactor
+	BlockFactor
+	StringFactor
 )
 
 func (ft FactorType) String() string {
 	switch ft {
 	case NumFactor:
 		return "numFactor"
+	case IndetFactor:
+		return "indetFactor"
 	case FuncFactor:
 		return "funcFactor"
 	case VarFactor:
 		return "varFactor"
 	case ParenFactor:
 		return "parenFactor"
+	case BlockFactor:
+		return "blockFactor"
+	case StringFactor:
+		return "stringFactor"
 	}
 
 	return "unknown"
@@ -86,12 +104,18 @@ type FactorNode struct {
 
 	Num *Token
 
+	Indet *Token
+
 	Function *Token
 	FuncArgs []*ExprNode
 
 	Variable *Token
 
 	ParenExpr *ExprNode
+
+	Blocks [][]*ExprNode // the rows of a block matrix
+
+	Str *Token
 }
 
 func (fnode *FactorNode) String() string {
@@ -106,6 +130,8 @@ func (fnode *FactorNode) String() string {
 	switch fnode.FType {
 	case NumFactor:
 		s += fmt.Sprintf(" <%s>", fnode.Num.TType)
+	case IndetFactor:
+		s += fmt.Sprintf(" <%s>", fnode.Indet.TType)
 	case FuncFactor:
 		argstrs := []string{}
 		for _, e := range fnode.FuncArgs {
@@ -116,6 +142,18 @@ func (fnode *FactorNode) String() string {
 		s += fmt.Sprintf(" <%s>", fnode.Variable.TType)
 	case ParenFactor:
 		s += fmt.Sprintf(" (%s)", fnode.ParenExpr)
+	case BlockFactor:
+		rowstrs := []string{}
+		for _, row := range fnode.Blocks {
+			argstrs := []string{}
+			for _, e := range row {
+				argstrs = append(argstrs, e.String())
+			}
+			rowstrs = append(rowstrs, strings.Join(argstrs, ","))
+		}
+		s += fmt.Sprintf(" [%s]", strings.Join(rowstrs, ";"))
+	case StringFactor:
+		s += fmt.Sprintf(" <%s>", fnode.Str.TType)
 	}
 
 	return s + ")"
@@ -145,6 +183,16 @@ func Parse(toks []*Token) (*ExprNode, error) {
 		return expr, err
 	}
 
+	if psr.peek().TType == TTMod {
+		psr.consume()
+
+		if psr.peek().TType != TTNum {
+			return expr, fmt.Errorf("expected %q but found %q", TTNum, psr.peek().TType)
+		}
+
+		expr.Modulus = psr.consume()
+	}
+
 	if psr.peek().TType == TTArrow {
 		psr.consume()
 
@@ -226,6 +274,20 @@ func parseFactor(psr *parser) (*FactorNode, error) {
 		return fnode, nil
 	}
 
+	if psr.peek().TType == TTString {
+		fnode.Str = psr.consume()
+
+		fnode.FType = StringFactor
+		return fnode, nil
+	}
+
+	if psr.peek().TType == TTIndet {
+		fnode.Indet = psr.consume()
+
+		fnode.FType = IndetFactor
+		return fnode, nil
+	}
+
 	if psr.peek().TType == TTFunc {
 		fnode.Function = psr.consume()
 
@@ -283,6 +345,34 @@ func parseFactor(psr *parser) (*FactorNode, error) {
 		return fnode, err
 	}
 
+	if psr.peek().TType == TTLBracket {
+		psr.consume()
+
+		for {
+			row, err := parseRow(psr)
+			if err != nil {
+				return fnode, err
+			}
+
+			fnode.Blocks = append(fnode.Blocks, row)
+
+			if psr.peek().TType != TTSemicolon {
+				break
+			}
+
+			psr.consume()
+		}
+
+		if psr.peek().TType != TTRBracket {
+			return fnode, fmt.Errorf("expected %q but found %q", TTRBracket, psr.peek().TType)
+		}
+
+		psr.consume()
+
+		fnode.FType = BlockFactor
+		return fnode, nil
+	}
+
 	if psr.peek().TType != TTMVar && psr.peek().TType != TTSVar && psr.peek().TType != TTDMVar && psr.peek().TType != TTDSVar && psr.peek().TType != TTDAMVar {
 		return fnode, fmt.Errorf("expected one of (%q, %q, %q, %q, %q) but found %q", TTMVar, TTSVar, TTDMVar, TTDSVar, TTDAMVar, psr.peek().TType)
 	}
@@ -292,3 +382,25 @@ func parseFactor(psr *parser) (*FactorNode, error) {
 	fnode.FType = VarFactor
 	return fnode, nil
 }
+
+func parseRow(psr *parser) ([]*ExprNode, error) {
+	expr, err := parseExpr(psr)
+	if err != nil {
+		return nil, err
+	}
+
+	row := []*ExprNode{expr}
+
+	for psr.peek().TType == TTComma {
+		psr.consume()
+
+		expr, err := parseExpr(psr)
+		if err != nil {
+			return nil, err
+		}
+
+		row = append(row, expr)
+	}
+
+	return row, nil
+}
@@ -18,6 +18,11 @@ func TestParse(t *testing.T) {
 		{TTMinus, TTNum, TTMult, TTMVar, TTEOF},
 		{TTNum, TTMult, TTLParen, TTFunc, TTLParen, TTDAMVar, TTPlus, TTMVar, TTRParen, TTMinus, TTNum, TTRParen, TTEOF},
 		{TTFunc, TTLParen, TTNum, TTComma, TTNum, TTRParen, TTEOF},
+		{TTFunc, TTLParen, TTMVar, TTRParen, TTMod, TTNum, TTArrow, TTMVar, TTEOF},
+		{TTMVar, TTMinus, TTIndet, TTMult, TTMVar, TTEOF},
+		{TTLBracket, TTMVar, TTComma, TTMVar, TTSemicolon, TTNum, TTComma, TTMVar, TTRBracket, TTEOF},
+		{TTString, TTEOF},
+		{TTFunc, TTLParen, TTMVar, TTComma, TTString, TTRParen, TTEOF},
 	}
 
 	toutputs := []string{
@@ -25,6 +30,11 @@ func TestParse(t *testing.T) {
 		"expr(term(factor(-numFactor <num>) <mult> factor(varFactor <mvar>)))",
 		"expr(term(factor(numFactor <num>) <mult> factor(parenFactor (expr(term(factor(funcFactor <func>(expr(term(factor(varFactor <damvar>)) <plus> term(factor(varFactor <mvar>)))))) <minus> term(factor(numFactor <num>)))))))",
 		"expr(term(factor(funcFactor <func>(expr(term(factor(numFactor <num>))),expr(term(factor(numFactor <num>)))))))",
+		"expr(term(factor(funcFactor <func>(expr(term(factor(varFactor <mvar>)))))) <mod> <num>)",
+		"expr(term(factor(varFactor <mvar>)) <minus> term(factor(indetFactor <indet>) <mult> factor(varFactor <mvar>)))",
+		"expr(term(factor(blockFactor [expr(term(factor(varFactor <mvar>))),expr(term(factor(varFactor <mvar>)));expr(term(factor(numFactor <num>))),expr(term(factor(varFactor <mvar>)))])))",
+		"expr(term(factor(stringFactor <string>)))",
+		"expr(term(factor(funcFactor <func>(expr(term(factor(varFactor <mvar>))),expr(term(factor(stringFactor <string>)))))))",
 	}
 
 	for i, types := range tinputs {
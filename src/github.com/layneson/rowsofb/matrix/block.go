@@ -0,0 +1,231 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+)
+
+//BlockDiagonal returns the block diagonal matrix with the given blocks along its diagonal and zeros elsewhere.
+func BlockDiagonal(blocks ...M) M {
+	r, c := 0, 0
+	for _, b := range blocks {
+		r += b.Rows()
+		c += b.Cols()
+	}
+
+	rm := New(r, c)
+
+	r, c = 0, 0
+	for _, b := range blocks {
+		for br := 1; br <= b.Rows(); br++ {
+			for bc := 1; bc <= b.Cols(); bc++ {
+				rm.Set(r+br, c+bc, b.Get(br, bc))
+			}
+		}
+
+		r += b.Rows()
+		c += b.Cols()
+	}
+
+	return rm
+}
+
+//Stack places b below a then returns this matrix.
+//It returns an error if the two matrices do not have the same number of columns.
+func Stack(a, b M) (M, error) {
+	if a.Cols() != b.Cols() {
+		return a, errors.New("stacked matrices must have equal column counts")
+	}
+
+	rm := New(a.Rows()+b.Rows(), a.Cols())
+
+	for c := 1; c <= a.Cols(); c++ {
+		for r := 1; r <= a.Rows(); r++ {
+			rm.Set(r, c, a.Get(r, c))
+		}
+
+		for r := 1; r <= b.Rows(); r++ {
+			rm.Set(a.Rows()+r, c, b.Get(r, c))
+		}
+	}
+
+	return rm, nil
+}
+
+//Block assembles a matrix from a grid of blocks, given as a list of block rows.
+//Every block in a block row must have the same number of rows, and every block in a block column the same number of columns.
+//The error names the first block, counting from 1, which does not fit.
+func Block(blocks [][]M) (M, error) {
+	g, err := blockGrid(gridsOf(blocks, func(b M) grid[Frac] { return b.grid }), NewScalarFrac(0))
+	if err != nil {
+		return New(0, 0), err
+	}
+
+	return M{grid: g, zero: NewScalarFrac(0)}, nil
+}
+
+//BlockFM assembles a floating-point matrix from a grid of blocks in the same way as Block.
+func BlockFM(blocks [][]FM) (FM, error) {
+	g, err := blockGrid(gridsOf(blocks, func(b FM) grid[float64] { return b.grid }), 0.0)
+
+	return FM{g}, err
+}
+
+//BlockPM assembles a polynomial matrix from a grid of blocks in the same way as Block.
+func BlockPM(blocks [][]PM) (PM, error) {
+	g, err := blockGrid(gridsOf(blocks, func(b PM) grid[Poly] { return b.grid }), Poly{})
+
+	return PM{g}, err
+}
+
+//BlockRM assembles a matrix of rational functions from a grid of blocks in the same way as Block.
+func BlockRM(blocks [][]RM) (RM, error) {
+	zero := RatFuncFromFrac(NewScalarFrac(0))
+
+	g, err := blockGrid(gridsOf(blocks, func(b RM) grid[RatFunc] { return b.grid }), zero)
+
+	return RM{grid: g, zero: zero}, err
+}
+
+func gridsOf[B, T any](blocks [][]B, g func(B) grid[T]) [][]grid[T] {
+	grids := make([][]grid[T], len(blocks))
+	for i, row := range blocks {
+		for _, b := range row {
+			grids[i] = append(grids[i], g(b))
+		}
+	}
+
+	return grids
+}
+
+func blockGrid[T any](blocks [][]grid[T], zero T) (grid[T], error) {
+	if len(blocks) == 0 || len(blocks[0]) == 0 {
+		return newGrid(0, 0, zero), errors.New("a block matrix needs at least one block")
+	}
+
+	widths := make([]int, len(blocks[0]))
+	for j, b := range blocks[0] {
+		widths[j] = b.Cols()
+	}
+
+	r, c := 0, 0
+	for _, b := range blocks[0] {
+		c += b.Cols()
+	}
+
+	for i, row := range blocks {
+		if len(row) != len(widths) {
+			return newGrid(0, 0, zero), fmt.Errorf("block row %d has %s, but block row 1 has %d", i+1, count(len(row), "block"), len(widths))
+		}
+
+		for j, b := range row {
+			if b.Rows() != row[0].Rows() {
+				return newGrid(0, 0, zero), fmt.Errorf("block (%d,%d) has %s, but block (%d,1) has %d", i+1, j+1, count(b.Rows(), "row"), i+1, row[0].Rows())
+			}
+
+			if b.Cols() != widths[j] {
+				return newGrid(0, 0, zero), fmt.Errorf("block (%d,%d) has %s, but block (1,%d) has %d", i+1, j+1, count(b.Cols(), "column"), j+1, widths[j])
+			}
+		}
+
+		r += row[0].Rows()
+	}
+
+	rm := newGrid(r, c, zero)
+
+	r = 0
+	for _, row := range blocks {
+		c = 0
+		for _, b := range row {
+			for br := 1; br <= b.Rows(); br++ {
+				for bc := 1; bc <= b.Cols(); bc++ {
+					rm.Set(r+br, c+bc, b.Get(br, bc))
+				}
+			}
+
+			c += b.Cols()
+		}
+
+		r += row[0].Rows()
+	}
+
+	return rm, nil
+}
+
+//Submatrix returns the block of m made of rows r1 through r2 and columns c1 through c2, inclusive.
+//It returns an error if the range is empty or falls outside of the matrix.
+func Submatrix(m M, r1, r2, c1, c2 int) (M, error) {
+	if r1 < 1 || r2 > m.Rows() || r1 > r2 {
+		return m, fmt.Errorf("rows %d through %d do not lie within a matrix with %d rows", r1, r2, m.Rows())
+	}
+
+	if c1 < 1 || c2 > m.Cols() || c1 > c2 {
+		return m, fmt.Errorf("columns %d through %d do not lie within a matrix with %d columns", c1, c2, m.Cols())
+	}
+
+	rm := New(r2-r1+1, c2-c1+1)
+
+	for r := 1; r <= rm.Rows(); r++ {
+		for c := 1; c <= rm.Cols(); c++ {
+			rm.Set(r, c, m.Get(r1+r-1, c1+c-1))
+		}
+	}
+
+	return rm, nil
+}
+
+//Partition splits m into a grid of blocks. The block rows have the heights in rows and the block columns the widths in cols;
+//each list must consist of positive sizes which add up to the matching dimension of m.
+//The result is indexed by block row, then block column.
+func Partition(m M, rows, cols []int) ([][]M, error) {
+	if err := checkPartition(rows, m.Rows(), "row"); err != nil {
+		return nil, err
+	}
+
+	if err := checkPartition(cols, m.Cols(), "column"); err != nil {
+		return nil, err
+	}
+
+	blocks := make([][]M, len(rows))
+
+	r := 1
+	for i, h := range rows {
+		c := 1
+		for _, w := range cols {
+			b, _ := Submatrix(m, r, r+h-1, c, c+w-1)
+			blocks[i] = append(blocks[i], b)
+
+			c += w
+		}
+
+		r += h
+	}
+
+	return blocks, nil
+}
+
+func checkPartition(sizes []int, total int, dim string) error {
+	sum := 0
+	for i, s := range sizes {
+		if s <= 0 {
+			return fmt.Errorf("block %s %d must have a positive size, not %d", dim, i+1, s)
+		}
+
+		sum += s
+	}
+
+	if sum != total {
+		return fmt.Errorf("block %s sizes add up to %d, but the matrix has %s", dim, sum, count(total, dim))
+	}
+
+	return nil
+}
+
+//count returns n followed by the noun, which is made plural unless n is one.
+func count(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", n, noun)
+}
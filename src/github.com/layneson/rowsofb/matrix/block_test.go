@@ -0,0 +1,93 @@
+package matrix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStack(t *testing.T) {
+	a := manualMatrix([][]string{
+		{"1", "2"},
+	})
+
+	b := manualMatrix([][]string{
+		{"3", "4"},
+		{"5", "6"},
+	})
+
+	expected := manualMatrix([][]string{
+		{"1", "2"},
+		{"3", "4"},
+		{"5", "6"},
+	})
+
+	if res, err := Stack(a, b); err != nil || !matrixEquals(res, expected) {
+		t.Errorf("Incorrect stacking! Wanted\n %v but got\n %v (error %v)", expected, res, err)
+	}
+
+	if _, err := Stack(a, Identity(3)); err == nil {
+		t.Error("Matrices with different column counts must not stack!")
+	}
+}
+
+func TestBlock(t *testing.T) {
+	a := manualMatrix([][]string{
+		{"1", "2"},
+		{"3", "4"},
+	})
+
+	b := manualMatrix([][]string{
+		{"5"},
+		{"6"},
+	})
+
+	c := manualMatrix([][]string{
+		{"7", "8"},
+	})
+
+	d := manualMatrix([][]string{
+		{"9"},
+	})
+
+	expected := manualMatrix([][]string{
+		{"1", "2", "5"},
+		{"3", "4", "6"},
+		{"7", "8", "9"},
+	})
+
+	res, err := Block([][]M{{a, b}, {c, d}})
+	if err != nil {
+		t.Fatalf("Got error during block construction: %v", err)
+	}
+
+	if !matrixEquals(res, expected) {
+		t.Errorf("Incorrect block matrix! Wanted\n %v but got\n %v", expected, res)
+	}
+
+	_, err = Block([][]M{{a, b}, {c, c}})
+	if err == nil || !strings.Contains(err.Error(), "block (2,2)") {
+		t.Errorf("Mismatched block (2,2) must be reported, but got error %v", err)
+	}
+
+	_, err = Block([][]M{{a, b}, {d, d}})
+	if err == nil || err.Error() != "block (2,1) has 1 column, but block (1,1) has 2" {
+		t.Errorf("Mismatched block (2,1) must be reported with a singular column count, but got error %v", err)
+	}
+
+	blocks, err := Partition(res, []int{2, 1}, []int{2, 1})
+	if err != nil {
+		t.Fatalf("Got error during partitioning: %v", err)
+	}
+
+	for i, row := range [][]M{{a, b}, {c, d}} {
+		for j, want := range row {
+			if !matrixEquals(blocks[i][j], want) {
+				t.Errorf("Incorrect block (%d,%d) after partitioning! Wanted\n %v but got\n %v", i+1, j+1, want, blocks[i][j])
+			}
+		}
+	}
+
+	if _, err := Partition(res, []int{1, 1}, []int{3}); err == nil {
+		t.Error("Row sizes which do not cover the matrix must be rejected!")
+	}
+}
@@ -0,0 +1,222 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+//ParseFormula counts the atoms of each element in a chemical formula such as "H2O", "Ca(OH)2" or "K4[Fe(CN)6]".
+//It returns the counts along with the elements in order of first appearance.
+func ParseFormula(s string) (map[string]int, []string, error) {
+	seen := map[string]bool{}
+	order := []string{}
+
+	//Each open group collects its own counts until it is closed and multiplied into the enclosing one.
+	stack := []map[string]int{{}}
+	closers := []rune{}
+
+	runes := []rune(s)
+	i := 0
+
+	readCount := func() int {
+		start := i
+		for i < len(runes) && unicode.IsDigit(runes[i]) {
+			i++
+		}
+
+		if i == start {
+			return 1
+		}
+
+		n := 0
+		for _, r := range runes[start:i] {
+			n = n*10 + int(r-'0')
+		}
+
+		return n
+	}
+
+	for i < len(runes) {
+		r := runes[i]
+
+		switch {
+		case unicode.IsUpper(r):
+			start := i
+			i++
+			for i < len(runes) && unicode.IsLower(runes[i]) {
+				i++
+			}
+
+			element := string(runes[start:i])
+			if !seen[element] {
+				seen[element] = true
+				order = append(order, element)
+			}
+
+			stack[len(stack)-1][element] += readCount()
+		case r == '(' || r == '[':
+			closer := ')'
+			if r == '[' {
+				closer = ']'
+			}
+
+			stack = append(stack, map[string]int{})
+			closers = append(closers, closer)
+			i++
+		case r == ')' || r == ']':
+			if len(closers) == 0 || closers[len(closers)-1] != r {
+				return nil, nil, fmt.Errorf("unbalanced %q in %q", r, s)
+			}
+
+			i++
+			n := readCount()
+
+			group := stack[len(stack)-1]
+			stack, closers = stack[:len(stack)-1], closers[:len(closers)-1]
+
+			for element, c := range group {
+				stack[len(stack)-1][element] += c * n
+			}
+		default:
+			return nil, nil, fmt.Errorf("unexpected %q in %q", r, s)
+		}
+	}
+
+	if len(closers) > 0 {
+		return nil, nil, fmt.Errorf("unclosed %q in %q", closers[len(closers)-1], s)
+	}
+
+	if len(order) == 0 {
+		return nil, nil, fmt.Errorf("%q contains no elements", s)
+	}
+
+	return stack[0], order, nil
+}
+
+//Reaction is a chemical equation: the formulas of its reactants and products, and the matrix of element counts
+//with a row for each element and a column for each species. Product columns are negated, so that a balancing is a
+//vector in the null space of the matrix.
+type Reaction struct {
+	Reactants, Products []string
+	Elements            []string
+	Counts              M
+}
+
+//ParseReaction parses a chemical equation such as "C3H8 + O2 -> CO2 + H2O". The sides may also be separated by "=" or "→".
+func ParseReaction(s string) (Reaction, error) {
+	rx := Reaction{}
+
+	sides := []string{}
+	for _, arrow := range []string{"->", "→", "="} {
+		if strings.Contains(s, arrow) {
+			sides = strings.Split(s, arrow)
+			break
+		}
+	}
+
+	if len(sides) != 2 {
+		return rx, errors.New("the equation must have one arrow between its reactants and products")
+	}
+
+	type species struct {
+		counts  map[string]int
+		product bool
+	}
+
+	all := []species{}
+
+	for side, text := range sides {
+		for _, f := range strings.Split(text, "+") {
+			f = strings.TrimSpace(f)
+
+			counts, order, err := ParseFormula(f)
+			if err != nil {
+				return rx, err
+			}
+
+			for _, element := range order {
+				found := false
+				for _, e := range rx.Elements {
+					found = found || e == element
+				}
+
+				if !found {
+					rx.Elements = append(rx.Elements, element)
+				}
+			}
+
+			if side == 0 {
+				rx.Reactants = append(rx.Reactants, f)
+			} else {
+				rx.Products = append(rx.Products, f)
+			}
+
+			all = append(all, species{counts, side == 1})
+		}
+	}
+
+	rx.Counts = New(len(rx.Elements), len(all))
+	for c, sp := range all {
+		for r, element := range rx.Elements {
+			n := sp.counts[element]
+			if sp.product {
+				n = -n
+			}
+
+			rx.Counts.Set(r+1, c+1, NewScalarFrac(n))
+		}
+	}
+
+	return rx, nil
+}
+
+//Balance returns the smallest positive integer coefficients which balance the reaction, reactants first.
+//An error is returned if the reaction cannot be balanced, or if it has several independent balancings.
+func (rx Reaction) Balance() ([]int, error) {
+	null := NullSpace(rx.Counts)
+
+	zero := true
+	for r := 1; r <= null.Rows(); r++ {
+		zero = zero && null.Get(r, 1).IsZero()
+	}
+
+	switch {
+	case zero:
+		return nil, errors.New("the equation cannot be balanced")
+	case null.Cols() > 1:
+		return nil, fmt.Errorf("the equation has %d independent balancings", null.Cols())
+	}
+
+	//Clear the denominators, then divide out the common factor.
+	l := 1
+	for r := 1; r <= null.Rows(); r++ {
+		d := null.Get(r, 1).Reduce().Denominator()
+		l = l / gcd(l, d) * d
+	}
+
+	coeffs := make([]int, null.Rows())
+	g := 0
+	for r := 1; r <= null.Rows(); r++ {
+		coeffs[r-1] = null.Get(r, 1).Mul(NewScalarFrac(l)).Reduce().Integer()
+		g = gcd(g, coeffs[r-1])
+	}
+
+	if g < 0 {
+		g = -g
+	}
+
+	if coeffs[0] < 0 {
+		g = -g
+	}
+
+	for i := range coeffs {
+		coeffs[i] /= g
+		if coeffs[i] <= 0 {
+			return nil, errors.New("the equation cannot be balanced with every species taking part")
+		}
+	}
+
+	return coeffs, nil
+}
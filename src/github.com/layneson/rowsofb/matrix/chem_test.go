@@ -0,0 +1,71 @@
+package matrix
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFormula(t *testing.T) {
+	tests := map[string]map[string]int{
+		"H2O":         {"H": 2, "O": 1},
+		"Ca(OH)2":     {"Ca": 1, "O": 2, "H": 2},
+		"K4[Fe(CN)6]": {"K": 4, "Fe": 1, "C": 6, "N": 6},
+		"Al2(SO4)3":   {"Al": 2, "S": 3, "O": 12},
+	}
+
+	for formula, expected := range tests {
+		counts, _, err := ParseFormula(formula)
+		if err != nil {
+			t.Errorf("Got error parsing %q: %v", formula, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(counts, expected) {
+			t.Errorf("Incorrect counts for %q! Wanted %v but got %v", formula, expected, counts)
+		}
+	}
+
+	for _, bad := range []string{"H2O)", "Ca(OH", "K4[Fe(CN]6)", "h2o", ""} {
+		if _, _, err := ParseFormula(bad); err == nil {
+			t.Errorf("Formula %q must be rejected!", bad)
+		}
+	}
+}
+
+func TestBalance(t *testing.T) {
+	tests := map[string][]int{
+		"C3H8 + O2 -> CO2 + H2O":                 {1, 5, 3, 4},
+		"Fe + O2 = Fe2O3":                        {4, 3, 2},
+		"Ca(OH)2 + H3PO4 -> Ca3(PO4)2 + H2O":     {3, 2, 1, 6},
+		"KMnO4 + HCl -> KCl + MnCl2 + H2O + Cl2": {2, 16, 2, 2, 8, 5},
+	}
+
+	for equation, expected := range tests {
+		rx, err := ParseReaction(equation)
+		if err != nil {
+			t.Errorf("Got error parsing %q: %v", equation, err)
+			continue
+		}
+
+		coeffs, err := rx.Balance()
+		if err != nil {
+			t.Errorf("Got error balancing %q: %v", equation, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(coeffs, expected) {
+			t.Errorf("Incorrect balancing of %q! Wanted %v but got %v", equation, expected, coeffs)
+		}
+	}
+
+	for _, bad := range []string{"H2 -> O2", "H2 + O2 + H2O2 -> H2O"} {
+		rx, err := ParseReaction(bad)
+		if err != nil {
+			t.Fatalf("Got error parsing %q: %v", bad, err)
+		}
+
+		if _, err := rx.Balance(); err == nil {
+			t.Errorf("Equation %q must not have a unique balancing!", bad)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//Hamming returns the generator matrix g and parity-check matrix h of the Hamming code with r parity bits,
+//which encodes messages of 2^r - r - 1 bits as codewords of 2^r - 1 bits and corrects any single error.
+//Both are in systematic form: g stacks the identity on top of a matrix a, and h is a followed by the identity,
+//where the columns of a are the r-bit vectors with at least two ones. Codewords are the column vectors g·m modulo 2.
+func Hamming(r int) (M, M, error) {
+	if r < 2 {
+		return M{}, M{}, fmt.Errorf("a Hamming code needs at least 2 parity bits, not %d", r)
+	}
+
+	n := 1<<uint(r) - 1
+	k := n - r
+
+	a := New(r, k)
+	c := 1
+	for v := 3; v <= n; v++ {
+		if v&(v-1) == 0 {
+			continue // powers of two have a single one; they are the identity columns
+		}
+
+		for i := 1; i <= r; i++ {
+			a.Set(i, c, NewScalarFrac(v>>uint(r-i)&1))
+		}
+		c++
+	}
+
+	g, _ := Block([][]M{{Identity(k)}, {a}})
+	h, _ := Block([][]M{{a, Identity(r)}})
+
+	return g, h, nil
+}
+
+//ParityCheck returns a parity-check matrix for the binary linear code with generator matrix g: its rows are a basis,
+//found by elimination modulo 2, for the words orthogonal to every column of g.
+//An error is returned if an entry of g is not an integer.
+func ParityCheck(g M) (M, error) {
+	null, err := NullSpaceMod(Transpose(g), 2)
+	if err != nil {
+		return M{}, err
+	}
+
+	return Transpose(null), nil
+}
+
+//Encode returns the codeword g·msg modulo 2 as a column vector.
+//An error is returned if msg is not a vector with one bit for each column of g.
+func Encode(g, msg M) (M, error) {
+	bits, err := bitVector(msg, g.Cols(), "message")
+	if err != nil {
+		return M{}, err
+	}
+
+	rm, _ := Multiply(g, bits)
+
+	return ReduceMod(rm, 2)
+}
+
+//Syndrome returns the syndrome h·word modulo 2 as a column vector. It is zero exactly when word is a codeword.
+//An error is returned if word is not a vector with one bit for each column of h.
+func Syndrome(h, word M) (M, error) {
+	bits, err := bitVector(word, h.Cols(), "word")
+	if err != nil {
+		return M{}, err
+	}
+
+	rm, _ := Multiply(h, bits)
+
+	return ReduceMod(rm, 2)
+}
+
+//CorrectError corrects a single bit error in word using the parity-check matrix h. An error in bit i gives the syndrome
+//equal to column i of h, so that bit is flipped. It returns the corrected word as a column vector and the position
+//of the flipped bit, counting from 1, or 0 if the syndrome is zero and word is already a codeword.
+//An error is returned if no column of h matches the syndrome, in which case more than one bit is wrong.
+func CorrectError(h, word M) (M, int, error) {
+	s, err := Syndrome(h, word)
+	if err != nil {
+		return M{}, 0, err
+	}
+
+	bits, _ := bitVector(word, h.Cols(), "word")
+
+	zero := true
+	for r := 1; r <= s.Rows(); r++ {
+		zero = zero && s.Get(r, 1).IsZero()
+	}
+
+	if zero {
+		return bits, 0, nil
+	}
+
+	hm, _ := ReduceMod(h, 2)
+	for c := 1; c <= hm.Cols(); c++ {
+		col, _ := Submatrix(hm, 1, hm.Rows(), c, c)
+		if col.Equals(s) {
+			bits.Set(c, 1, NewScalarFrac(1-bits.Get(c, 1).Integer()))
+			return bits, c, nil
+		}
+	}
+
+	return M{}, 0, fmt.Errorf("the syndrome %s matches no column of the parity-check matrix, so more than one bit is wrong", FormatBits(s))
+}
+
+//ParseBits parses a string of zeros and ones, such as "1011", as a column vector. Spaces are ignored.
+func ParseBits(s string) (M, error) {
+	s = strings.Join(strings.Fields(s), "")
+	if s == "" {
+		return M{}, errors.New("expected a string of bits")
+	}
+
+	rm := New(len(s), 1)
+	for i, r := range s {
+		switch r {
+		case '0':
+		case '1':
+			rm.Set(i+1, 1, NewScalarFrac(1))
+		default:
+			return M{}, fmt.Errorf("%q is not a bit; expected only 0 and 1", r)
+		}
+	}
+
+	return rm, nil
+}
+
+//FormatBits writes the entries of a vector modulo 2 as a string of zeros and ones.
+//Entries which are not integers are written as question marks.
+func FormatBits(m M) string {
+	var sb strings.Builder
+
+	for r := 1; r <= m.Rows(); r++ {
+		for c := 1; c <= m.Cols(); c++ {
+			f, err := m.Get(r, c).Mod(2)
+			switch {
+			case err != nil:
+				sb.WriteByte('?')
+			case f.IsZero():
+				sb.WriteByte('0')
+			default:
+				sb.WriteByte('1')
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+//bitVector reduces a row or column vector of n entries modulo 2 and returns it as a column vector.
+func bitVector(v M, n int, name string) (M, error) {
+	entries, err := VectorEntries(v)
+	if err != nil {
+		return M{}, err
+	}
+
+	if len(entries) != n {
+		return M{}, fmt.Errorf("the %s has %d bits, but %d are needed", name, len(entries), n)
+	}
+
+	rm := New(n, 1)
+	for i, f := range entries {
+		rm.Set(i+1, 1, f)
+	}
+
+	return ReduceMod(rm, 2)
+}
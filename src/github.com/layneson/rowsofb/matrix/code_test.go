@@ -0,0 +1,73 @@
+package matrix
+
+import "testing"
+
+func TestHamming(t *testing.T) {
+	g, h, err := Hamming(3)
+	if err != nil {
+		t.Fatalf("Got error while building the Hamming code: %v", err)
+	}
+
+	if g.Rows() != 7 || g.Cols() != 4 || h.Rows() != 3 || h.Cols() != 7 {
+		t.Fatalf("The [7,4] Hamming code has a 7x4 generator and 3x7 parity check, not %dx%d and %dx%d", g.Rows(), g.Cols(), h.Rows(), h.Cols())
+	}
+
+	hg, _ := Multiply(h, g)
+	hg, _ = ReduceMod(hg, 2)
+	if !matrixEquals(hg, New(3, 4)) {
+		t.Errorf("HG should be zero modulo 2 but was\n %v", hg)
+	}
+
+	pc, err := ParityCheck(g)
+	if err != nil {
+		t.Fatalf("Got error while finding a parity-check matrix: %v", err)
+	}
+
+	if !matrixEquals(pc, h) {
+		t.Errorf("The parity-check matrix of G should be\n %v but was\n %v", h, pc)
+	}
+
+	if _, _, err := Hamming(1); err == nil {
+		t.Error("A Hamming code with 1 parity bit should be rejected")
+	}
+}
+
+func TestHammingCorrection(t *testing.T) {
+	g, h, _ := Hamming(3)
+
+	msg, _ := ParseBits("1011")
+	c, err := Encode(g, msg)
+	if err != nil {
+		t.Fatalf("Got error during encoding: %v", err)
+	}
+
+	if FormatBits(c) != "1011010" {
+		t.Errorf("1011 should encode to 1011010 but encoded to %s", FormatBits(c))
+	}
+
+	if s, _ := Syndrome(h, c); FormatBits(s) != "000" {
+		t.Errorf("A codeword should have syndrome 000, not %s", FormatBits(s))
+	}
+
+	for i := 1; i <= 7; i++ {
+		word := CopyMatrix(c)
+		word.Set(i, 1, NewScalarFrac(1-word.Get(i, 1).Integer()))
+
+		fixed, pos, err := CorrectError(h, word)
+		if err != nil {
+			t.Fatalf("Got error while correcting bit %d: %v", i, err)
+		}
+
+		if pos != i || !matrixEquals(fixed, c) {
+			t.Errorf("Flipping bit %d should be corrected, but bit %d was flipped to give %s", i, pos, FormatBits(fixed))
+		}
+	}
+
+	if _, err := Encode(g, c); err == nil {
+		t.Error("Encoding a 7-bit message with a [7,4] code should fail")
+	}
+
+	if _, err := ParseBits("10201"); err == nil {
+		t.Error("10201 should not parse as bits")
+	}
+}
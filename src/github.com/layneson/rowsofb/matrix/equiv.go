@@ -0,0 +1,314 @@
+package matrix
+
+import "errors"
+
+//RowEquivalent returns true if b can be obtained from a by elementary row operations, along with an invertible
+//matrix E such that EA = B. Two matrices are row equivalent exactly when they have the same size and reduced row echelon form.
+func RowEquivalent(a, b M) (M, bool) {
+	if a.Rows() != b.Rows() || a.Cols() != b.Cols() {
+		return M{}, false
+	}
+
+	ea, ra := rrefTransform(a)
+	eb, rb := rrefTransform(b)
+
+	if !ra.Equals(rb) {
+		return M{}, false
+	}
+
+	ebi, _ := Inverse(eb) // records of row operations are always invertible
+
+	e, _ := Multiply(ebi, ea)
+
+	return e, true
+}
+
+//rrefTransform returns the reduced row echelon form R of m along with the invertible matrix E such that EM = R.
+func rrefTransform(m M) (M, M) {
+	aug, _ := Augment(m, Identity(m.Rows()))
+	aug = Rref(aug)
+
+	e := New(m.Rows(), m.Rows())
+	r := New(m.Rows(), m.Cols())
+
+	for row := 1; row <= m.Rows(); row++ {
+		for c := 1; c <= m.Cols(); c++ {
+			r.Set(row, c, aug.Get(row, c))
+		}
+
+		for c := 1; c <= m.Rows(); c++ {
+			e.Set(row, c, aug.Get(row, m.Cols()+c))
+		}
+	}
+
+	return e, r
+}
+
+//SmithDiagonal returns the diagonal of the Smith normal form of a square polynomial matrix: monic polynomials
+//d1, d2, ..., dn, each dividing the next, such that m is equivalent to diag(d1, ..., dn) by invertible row and
+//column operations. Zero entries of the diagonal come last. An error is returned if the matrix is not square.
+func SmithDiagonal(m PM) ([]Poly, error) {
+	if m.Rows() != m.Cols() {
+		return nil, errors.New("the matrix must be square")
+	}
+
+	diag, _ := smith(m)
+
+	return diag, nil
+}
+
+//smith returns the diagonal of the Smith normal form of a square polynomial matrix m, along with the inverse of the
+//product U of the row operations used, so that U·m·V = diag(d1, ..., dn) for some invertible V.
+func smith(m PM) ([]Poly, PM) {
+	m = CopyPM(m)
+	n := m.Rows()
+
+	// uinv starts as the identity and undoes each row operation as a column operation, keeping it the inverse of U
+	uinv := PMFromMatrix(Identity(n))
+
+	swapRows := func(r1, r2 int) {
+		for c := 1; c <= n; c++ {
+			tmp := m.Get(r1, c)
+			m.Set(r1, c, m.Get(r2, c))
+			m.Set(r2, c, tmp)
+
+			tmp = uinv.Get(c, r1)
+			uinv.Set(c, r1, uinv.Get(c, r2))
+			uinv.Set(c, r2, tmp)
+		}
+	}
+
+	// addRow adds q times row src to row dst
+	addRow := func(dst, src int, q Poly) {
+		for c := 1; c <= n; c++ {
+			m.Set(dst, c, m.Get(dst, c).Add(q.Mul(m.Get(src, c))))
+			uinv.Set(c, src, uinv.Get(c, src).Add(q.Mul(uinv.Get(c, dst)).Neg()))
+		}
+	}
+
+	swapCols := func(c1, c2 int) {
+		for r := 1; r <= n; r++ {
+			tmp := m.Get(r, c1)
+			m.Set(r, c1, m.Get(r, c2))
+			m.Set(r, c2, tmp)
+		}
+	}
+
+	diag := []Poly{}
+
+	for k := 1; k <= n; k++ {
+		for {
+			// move an entry of least degree to the pivot position
+			pr, pc := 0, 0
+			for r := k; r <= n; r++ {
+				for c := k; c <= n; c++ {
+					p := m.Get(r, c)
+					if !p.IsZero() && (pr == 0 || p.Degree() < m.Get(pr, pc).Degree()) {
+						pr, pc = r, c
+					}
+				}
+			}
+
+			if pr == 0 { // the rest of the matrix is zero
+				for ; k <= n; k++ {
+					diag = append(diag, Poly{})
+				}
+
+				return diag, uinv
+			}
+
+			swapRows(k, pr)
+			swapCols(k, pc)
+
+			pivot := m.Get(k, k)
+			clean := true
+
+			for r := k + 1; r <= n; r++ {
+				q, rem := m.Get(r, k).DivMod(pivot)
+				addRow(r, k, q.Neg())
+				clean = clean && rem.IsZero()
+			}
+
+			for c := k + 1; c <= n; c++ {
+				q, rem := m.Get(k, c).DivMod(pivot)
+				for r := k; r <= n; r++ {
+					m.Set(r, c, m.Get(r, c).Add(q.Mul(m.Get(r, k)).Neg()))
+				}
+				clean = clean && rem.IsZero()
+			}
+
+			if !clean { // a remainder of smaller degree is left, so pivot on it
+				continue
+			}
+
+			// the pivot must divide every remaining entry, otherwise fold the offending row into the pivot row
+			divides := true
+			for r := k + 1; r <= n && divides; r++ {
+				for c := k + 1; c <= n; c++ {
+					if _, rem := m.Get(r, c).DivMod(pivot); !rem.IsZero() {
+						addRow(k, r, NewScalarPoly(NewScalarFrac(1)))
+						divides = false
+						break
+					}
+				}
+			}
+
+			if divides {
+				break
+			}
+		}
+
+		diag = append(diag, m.Get(k, k).Monic())
+	}
+
+	return diag, uinv
+}
+
+//InvariantFactors returns the invariant factors of a square matrix: the non-constant entries of the Smith normal form
+//of xI - m, each dividing the next. Their product is the characteristic polynomial and the last one is the minimal polynomial.
+//An error is returned if the matrix is not square.
+func InvariantFactors(m M) ([]Poly, error) {
+	if m.Rows() != m.Cols() {
+		return nil, errors.New("non-square matrices have no invariant factors")
+	}
+
+	xi := ScalePM(PolyX(), PMFromMatrix(Identity(m.Rows())))
+	xia, _ := AddPM(xi, ScalePM(NewScalarPoly(NewScalarFrac(-1)), PMFromMatrix(m)))
+
+	diag, _ := SmithDiagonal(xia)
+
+	factors := []Poly{}
+	for _, d := range diag {
+		if !d.IsConstant() {
+			factors = append(factors, d)
+		}
+	}
+
+	return factors, nil
+}
+
+//Similar returns true if the square matrices a and b are similar, which is the case exactly when they have the same invariant factors.
+//An error is returned if either matrix is not square.
+func Similar(a, b M) (bool, error) {
+	fa, err := InvariantFactors(a)
+	if err != nil {
+		return false, err
+	}
+
+	fb, err := InvariantFactors(b)
+	if err != nil {
+		return false, err
+	}
+
+	if a.Rows() != b.Rows() || len(fa) != len(fb) {
+		return false, nil
+	}
+
+	for i := range fa {
+		if !fa[i].Equals(fb[i]) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+//SimilarityTransform returns an invertible matrix P such that P⁻¹AP = B, or false if a and b are not similar.
+//Both matrices are brought to their common rational canonical form F by transition matrices Pa and Pb built from
+//cyclic vectors, and P = Pa·Pb⁻¹. The result is checked against AP = PB before it is returned.
+func SimilarityTransform(a, b M) (M, bool) {
+	n := a.Rows()
+	if a.Cols() != n || b.Rows() != n || b.Cols() != n {
+		return M{}, false
+	}
+
+	fa, pa, err := Frobenius(a)
+	if err != nil {
+		return M{}, false
+	}
+
+	fb, pb, err := Frobenius(b)
+	if err != nil || !fa.Equals(fb) {
+		return M{}, false
+	}
+
+	pbi, err := Inverse(pb)
+	if err != nil {
+		return M{}, false
+	}
+
+	p, _ := Multiply(pa, pbi)
+
+	ap, _ := Multiply(a, p)
+	pb, _ = Multiply(p, b)
+	if !ap.Equals(pb) {
+		return M{}, false
+	}
+
+	return p, true
+}
+
+//Frobenius returns the rational canonical form F of a square matrix, which is block diagonal with the companion
+//matrices of its invariant factors, along with a transition matrix P such that P⁻¹mP = F.
+//Unlike the Jordan form, it never needs the roots of the characteristic polynomial.
+//Each invariant factor f of degree d has a cyclic vector v, whose annihilator is f, and the vectors v, mv, ..., m^{d-1}v
+//are the columns of P for its block. The vectors come from the Smith normal form of xI - m: if U(xI - m)V is diagonal,
+//the columns of U⁻¹ belonging to the invariant factors, read as sums of polynomials in m applied to the standard basis,
+//generate cyclic subspaces whose direct sum is every vector.
+//An error is returned if the matrix is not square, or if the arithmetic overflows so that mP and PF differ.
+func Frobenius(m M) (M, M, error) {
+	n := m.Rows()
+	if n != m.Cols() {
+		return m, m, errors.New("non-square matrices have no rational canonical form")
+	}
+
+	xi := ScalePM(PolyX(), PMFromMatrix(Identity(n)))
+	xia, _ := AddPM(xi, ScalePM(NewScalarPoly(NewScalarFrac(-1)), PMFromMatrix(m)))
+
+	diag, uinv := smith(xia)
+
+	blocks := []M{}
+	columns := []M{}
+	for i, d := range diag {
+		if d.IsConstant() {
+			continue
+		}
+
+		blocks = append(blocks, Companion(d))
+
+		v := cyclicVector(m, uinv, i+1)
+		for j := 0; j < d.Degree(); j++ {
+			columns = append(columns, v)
+			v, _ = Multiply(m, v)
+		}
+	}
+
+	f := BlockDiagonal(blocks...)
+	p, _ := Block([][]M{columns})
+
+	mp, _ := Multiply(m, p)
+	pf, _ := Multiply(p, f)
+	if det, _ := Determinant(p); det.IsZero() || !mp.Equals(pf) {
+		return m, m, errors.New("the transition matrix to the rational canonical form could not be computed exactly")
+	}
+
+	return f, p, nil
+}
+
+//cyclicVector returns the vector q1(m)e1 + q2(m)e2 + ... + qn(m)en, where q is the given column of the polynomial matrix.
+func cyclicVector(m M, q PM, col int) M {
+	n := m.Rows()
+	v := New(n, 1)
+
+	for j := 1; j <= n; j++ {
+		qm, _ := q.Get(j, col).EvalMatrix(m)
+
+		e := New(n, 1)
+		e.Set(j, 1, NewScalarFrac(1))
+
+		qe, _ := Multiply(qm, e)
+		v, _ = Add(v, qe)
+	}
+
+	return v
+}
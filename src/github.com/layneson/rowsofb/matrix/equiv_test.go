@@ -0,0 +1,230 @@
+package matrix
+
+import "testing"
+
+func TestRowEquivalent(t *testing.T) {
+	a := manualMatrix([][]string{
+		{"1", "2"},
+		{"3", "4"},
+	})
+
+	b := manualMatrix([][]string{
+		{"3", "4"},
+		{"2", "4"},
+	})
+
+	e, ok := RowEquivalent(a, b)
+	if !ok {
+		t.Fatalf("Invertible matrices must be row equivalent!")
+	}
+
+	if product, _ := Multiply(e, a); !matrixEquals(product, b) {
+		t.Errorf("EA should be\n %v but was\n %v", b, product)
+	}
+
+	singular := manualMatrix([][]string{
+		{"1", "2"},
+		{"2", "4"},
+	})
+
+	if _, ok := RowEquivalent(a, singular); ok {
+		t.Error("Matrices of different rank must not be row equivalent!")
+	}
+}
+
+func TestInvariantFactors(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"2", "0", "0"},
+		{"0", "3", "0"},
+		{"0", "0", "2"},
+	})
+
+	factors, err := InvariantFactors(input)
+	if err != nil {
+		t.Fatalf("Got error computing invariant factors: %v", err)
+	}
+
+	expected := []Poly{manualPoly(-2, 1), manualPoly(6, -5, 1)}
+
+	if len(factors) != len(expected) {
+		t.Fatalf("Invariant factors should be %v but were %v", expected, factors)
+	}
+
+	for i := range expected {
+		if !factors[i].Equals(expected[i]) {
+			t.Errorf("Invariant factors should be %v but were %v", expected, factors)
+		}
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	a := manualMatrix([][]string{
+		{"1", "1"},
+		{"0", "2"},
+	})
+
+	b := manualMatrix([][]string{
+		{"1", "0"},
+		{"0", "2"},
+	})
+
+	similar, err := Similar(a, b)
+	if err != nil {
+		t.Fatalf("Got error testing similarity: %v", err)
+	}
+
+	if !similar {
+		t.Fatalf("Matrices with the same distinct eigenvalues must be similar!")
+	}
+
+	p, ok := SimilarityTransform(a, b)
+	if !ok {
+		t.Fatalf("No similarity transform was found!")
+	}
+
+	ap, _ := Multiply(a, p)
+	pb, _ := Multiply(p, b)
+
+	if !matrixEquals(ap, pb) {
+		t.Errorf("AP should equal PB for P =\n %v", p)
+	}
+
+	jordan := manualMatrix([][]string{
+		{"2", "1"},
+		{"0", "2"},
+	})
+
+	if similar, _ := Similar(jordan, Scale(NewScalarFrac(2), Identity(2))); similar {
+		t.Error("A Jordan block must not be similar to a scalar matrix!")
+	}
+}
+
+func TestFrobenius(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"2", "0", "0"},
+		{"0", "3", "0"},
+		{"0", "0", "2"},
+	})
+
+	f, p, err := Frobenius(input)
+	if err != nil {
+		t.Fatalf("Got error computing the rational canonical form: %v", err)
+	}
+
+	expected := manualMatrix([][]string{
+		{"2", "0", "0"},
+		{"0", "0", "-6"},
+		{"0", "1", "5"},
+	})
+
+	if !matrixEquals(f, expected) {
+		t.Errorf("Incorrect rational canonical form! Wanted\n %v but got\n %v", expected, f)
+	}
+
+	pi, err := Inverse(p)
+	if err != nil {
+		t.Fatalf("Transition matrix must be invertible but was\n %v", p)
+	}
+
+	product, _ := Multiply(pi, input)
+	product, _ = Multiply(product, p)
+
+	if !matrixEquals(product, f) {
+		t.Errorf("P⁻¹AP should be\n %v but was\n %v", f, product)
+	}
+}
+
+func TestFrobeniusDense(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"3", "1", "4", "1"},
+		{"5", "9", "2", "6"},
+		{"5", "3", "5", "8"},
+		{"9", "7", "9", "3"},
+	})
+
+	f, p, err := Frobenius(input)
+	if err != nil {
+		t.Fatalf("Got error computing the rational canonical form: %v", err)
+	}
+
+	cp, _ := CharPoly(input)
+	if expected := Companion(cp); !matrixEquals(f, expected) {
+		t.Errorf("A matrix with a cyclic vector has the companion matrix of its characteristic polynomial as its form! Wanted\n %v but got\n %v", expected, f)
+	}
+
+	if det, _ := Determinant(p); det.IsZero() {
+		t.Fatalf("Transition matrix must be invertible but was\n %v", p)
+	}
+
+	ap, _ := Multiply(input, p)
+	pf, _ := Multiply(p, f)
+
+	if !matrixEquals(ap, pf) {
+		t.Errorf("AP should equal PF for P =\n %v", p)
+	}
+}
+
+func TestFrobeniusRepeatedFactors(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"2", "1", "0", "0"},
+		{"0", "2", "0", "0"},
+		{"1", "0", "2", "1"},
+		{"0", "-1", "0", "2"},
+	})
+
+	f, p, err := Frobenius(input)
+	if err != nil {
+		t.Fatalf("Got error computing the rational canonical form: %v", err)
+	}
+
+	if det, _ := Determinant(p); det.IsZero() {
+		t.Fatalf("Transition matrix must be invertible but was\n %v", p)
+	}
+
+	ap, _ := Multiply(input, p)
+	pf, _ := Multiply(p, f)
+
+	if !matrixEquals(ap, pf) {
+		t.Errorf("AP should equal PF for P =\n %v and F =\n %v", p, f)
+	}
+}
+
+func TestSimilarityTransformDense(t *testing.T) {
+	a := manualMatrix([][]string{
+		{"3", "1", "4", "1"},
+		{"5", "9", "2", "6"},
+		{"5", "3", "5", "8"},
+		{"9", "7", "9", "3"},
+	})
+
+	q := manualMatrix([][]string{
+		{"1", "2", "0", "0"},
+		{"0", "1", "1", "0"},
+		{"0", "0", "1", "-1"},
+		{"1", "0", "0", "1"},
+	})
+
+	qi, err := Inverse(q)
+	if err != nil {
+		t.Fatalf("Got error inverting\n %v: %v", q, err)
+	}
+
+	b, _ := Multiply(qi, a)
+	b, _ = Multiply(b, q)
+
+	p, ok := SimilarityTransform(a, b)
+	if !ok {
+		t.Fatalf("No similarity transform was found!")
+	}
+
+	ap, _ := Multiply(a, p)
+	pb, _ := Multiply(p, b)
+
+	if !matrixEquals(ap, pb) {
+		t.Errorf("AP should equal PB for P =\n %v", p)
+	}
+
+	if _, ok := SimilarityTransform(a, Identity(4)); ok {
+		t.Error("A matrix must not be similar to the identity unless it is the identity!")
+	}
+}
@@ -0,0 +1,156 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+)
+
+//FullRankFactorization factors m into C times F, where C holds the pivot columns of m and F holds
+//the nonzero rows of the reduced row echelon form of m. Both have as many columns and rows, respectively, as the rank of m.
+func FullRankFactorization(m M) (M, M) {
+	rref := Rref(m)
+
+	pivots := []int{}
+	for r := 1; r <= rref.Rows(); r++ {
+		for c := 1; c <= rref.Cols(); c++ {
+			if isLeadingEntry(rref, r, c) {
+				pivots = append(pivots, c)
+				break
+			}
+		}
+	}
+
+	cm := New(m.Rows(), len(pivots))
+	fm := New(len(pivots), m.Cols())
+
+	for i, pc := range pivots {
+		for r := 1; r <= m.Rows(); r++ {
+			cm.Set(r, i+1, m.Get(r, pc))
+		}
+
+		for c := 1; c <= m.Cols(); c++ {
+			fm.Set(i+1, c, rref.Get(i+1, c))
+		}
+	}
+
+	return cm, fm
+}
+
+//PseudoInverse returns the Moore-Penrose pseudoinverse of a matrix of any shape.
+//It is computed exactly from the full-rank factorization m = CF as Fᵀ(FFᵀ)⁻¹(CᵀC)⁻¹Cᵀ.
+func PseudoInverse(m M) M {
+	cm, fm := FullRankFactorization(m)
+
+	if cm.Cols() == 0 { // the zero matrix is its own pseudoinverse, transposed
+		return New(m.Cols(), m.Rows())
+	}
+
+	ft := Transpose(fm)
+	ct := Transpose(cm)
+
+	// C and F have full rank, so both Gram matrices are invertible
+	ffi, _ := Multiply(fm, ft)
+	ffi, _ = Inverse(ffi)
+
+	cci, _ := Multiply(ct, cm)
+	cci, _ = Inverse(cci)
+
+	rm, _ := Multiply(ft, ffi)
+	rm, _ = Multiply(rm, cci)
+	rm, _ = Multiply(rm, ct)
+
+	return rm
+}
+
+//PivotError is returned by a factorization which breaks down at a pivot.
+type PivotError struct {
+	Pivot int  // one-based index of the offending pivot
+	Value Frac // value of the pivot
+	msg   string
+}
+
+func (e PivotError) Error() string {
+	return fmt.Sprintf("pivot %d is %v, so %s", e.Pivot, e.Value, e.msg)
+}
+
+func checkSymmetric(m M) error {
+	if m.Rows() != m.Cols() {
+		return errors.New("the matrix must be square")
+	}
+
+	if !m.Equals(Transpose(m)) {
+		return errors.New("the matrix must be symmetric")
+	}
+
+	return nil
+}
+
+//LDL factors a symmetric positive definite matrix m into L D Lᵀ, where L is unit lower triangular and D is diagonal.
+//The diagonal of D holds the pivots of row reduction without row exchanges, and L holds the multiples of each pivot row
+//which were subtracted, both read off the row operations of Ref.
+//An error is returned if m is not symmetric, or a PivotError naming the first pivot which is not positive.
+func LDL(m M) (M, M, error) {
+	if err := checkSymmetric(m); err != nil {
+		return m, m, err
+	}
+
+	n := m.Rows()
+	ref, ops := RefOps(m)
+
+	l := Identity(n)
+	d := Identity(n) // a pivot which is already one is not scaled
+	exchange := n + 1
+
+	for _, op := range ops {
+		switch op.Kind {
+		case SwitchOp: // the pivot in row R1 is zero, but an entry below it is not
+			if op.R1 < exchange {
+				exchange = op.R1
+			}
+		case ScaleOp:
+			d.Set(op.R1, op.R1, op.Scalar.Reciprocal())
+		case AddOp: // the pivot row has been scaled to one, so its multiple is the entry below the pivot
+			l.Set(op.R2, op.R1, op.Scalar.Neg().Div(d.Get(op.R1, op.R1)))
+		}
+	}
+
+	for k := 1; k <= n; k++ {
+		if k >= exchange || ref.Get(k, k).IsZero() {
+			return m, m, PivotError{Pivot: k, Value: NewScalarFrac(0), msg: "the matrix is not positive definite"}
+		}
+
+		if d.Get(k, k).Numerator() <= 0 {
+			return m, m, PivotError{Pivot: k, Value: d.Get(k, k), msg: "the matrix is not positive definite"}
+		}
+	}
+
+	return l, d, nil
+}
+
+//Cholesky factors a symmetric positive definite matrix m into L Lᵀ, where L is lower triangular with a positive diagonal.
+//Entries of L are radicals where the pivots are not perfect squares.
+//It is the LDLᵀ factorization with the square root of D folded into L.
+//An error is returned if m is not symmetric, or a PivotError naming the first pivot which is not positive.
+func Cholesky(m M) (RadM, error) {
+	if err := checkSymmetric(m); err != nil {
+		return RadM{}, err
+	}
+
+	l, d, err := LDL(m)
+	if err != nil {
+		return RadM{}, err
+	}
+
+	n := m.Rows()
+	rm := NewRadM(n, n)
+
+	for c := 1; c <= n; c++ {
+		root, _ := SqrtFrac(d.Get(c, c))
+
+		for r := c; r <= n; r++ {
+			rm.Set(r, c, root.Scale(l.Get(r, c)))
+		}
+	}
+
+	return rm, nil
+}
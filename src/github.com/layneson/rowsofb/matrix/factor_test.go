@@ -0,0 +1,158 @@
+package matrix
+
+import "testing"
+
+func TestPseudoInverseInvertible(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"2", "6", "8"},
+		{"6", "18", "25"},
+		{"6", "17", "32"},
+	})
+
+	expected, err := Inverse(input)
+	if err != nil {
+		t.Fatalf("Got error during inverse calculation: %v", err)
+	}
+
+	if res := PseudoInverse(input); !matrixEquals(res, expected) {
+		t.Errorf("Pseudoinverse of an invertible matrix should be\n %v but was\n %v", expected, res)
+	}
+}
+
+func TestPseudoInverse(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"1", "2", "3"},
+		{"2", "4", "6"},
+	})
+
+	res := PseudoInverse(input)
+
+	expected := manualMatrix([][]string{
+		{"1/70", "1/35"},
+		{"1/35", "2/35"},
+		{"3/70", "3/35"},
+	})
+
+	if !matrixEquals(res, expected) {
+		t.Errorf("Incorrect pseudoinverse! Wanted\n %v but got\n %v", expected, res)
+	}
+
+	aaa, _ := Multiply(input, res)
+	aaa, _ = Multiply(aaa, input)
+
+	if !matrixEquals(aaa, input) {
+		t.Errorf("A A⁺ A should equal A but was\n %v", aaa)
+	}
+
+	zero := PseudoInverse(New(2, 3))
+	if !matrixEquals(zero, New(3, 2)) {
+		t.Errorf("Pseudoinverse of the zero matrix should be zero but was\n %v", zero)
+	}
+}
+
+func TestLDL(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"4", "2", "-2"},
+		{"2", "5", "1"},
+		{"-2", "1", "3"},
+	})
+
+	l, d, err := LDL(input)
+	if err != nil {
+		t.Fatalf("Got error during LDLᵀ factorization: %v", err)
+	}
+
+	expectedL := manualMatrix([][]string{
+		{"1", "0", "0"},
+		{"1/2", "1", "0"},
+		{"-1/2", "1/2", "1"},
+	})
+
+	expectedD := manualMatrix([][]string{
+		{"4", "0", "0"},
+		{"0", "4", "0"},
+		{"0", "0", "1"},
+	})
+
+	if !matrixEquals(l, expectedL) || !matrixEquals(d, expectedD) {
+		t.Errorf("Incorrect LDLᵀ factorization! Got L =\n %v and D =\n %v", l, d)
+	}
+
+	tests := []struct {
+		input [][]string
+		pivot int
+		value Frac
+	}{
+		{[][]string{{"4", "2", "-2"}, {"2", "5", "1"}, {"-2", "1", "1"}}, 3, NewScalarFrac(-1)},
+		{[][]string{{"1", "2"}, {"2", "1"}}, 2, NewScalarFrac(-3)},
+		{[][]string{{"-1", "0"}, {"0", "-1"}}, 1, NewScalarFrac(-1)},
+		{[][]string{{"0", "1"}, {"1", "0"}}, 1, NewScalarFrac(0)},
+		{[][]string{{"1", "0", "0"}, {"0", "0", "0"}, {"0", "0", "1"}}, 2, NewScalarFrac(0)},
+	}
+
+	for _, test := range tests {
+		_, _, err = LDL(manualMatrix(test.input))
+
+		if perr, ok := err.(PivotError); !ok || perr.Pivot != test.pivot || !fractionEquals(perr.Value, test.value) {
+			t.Errorf("LDLᵀ factorization of %v must fail at pivot %d = %v, but got error %v", test.input, test.pivot, test.value, err)
+		}
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"4", "2"},
+		{"2", "3"},
+	})
+
+	l, err := Cholesky(input)
+	if err != nil {
+		t.Fatalf("Got error during Cholesky factorization: %v", err)
+	}
+
+	expected := [][]string{
+		{"2", "0"},
+		{"1", "√2"},
+	}
+
+	for r := 1; r <= 2; r++ {
+		for c := 1; c <= 2; c++ {
+			if l.Get(r, c).String() != expected[r-1][c-1] {
+				t.Errorf("Entry %d,%d of the Cholesky factor should be %s but was %v", r, c, expected[r-1][c-1], l.Get(r, c))
+			}
+		}
+	}
+
+	_, err = Cholesky(manualMatrix([][]string{
+		{"1", "2"},
+		{"2", "1"},
+	}))
+
+	if perr, ok := err.(PivotError); !ok || perr.Pivot != 2 || !fractionEquals(perr.Value, NewScalarFrac(-3)) {
+		t.Errorf("Cholesky factorization must fail at pivot 2 = -3, but got error %v", err)
+	}
+}
+
+func TestSqrtFrac(t *testing.T) {
+	tests := map[string]Frac{
+		"(2/3)√6": NewFrac(8, 3),
+		"3/2":     NewFrac(9, 4),
+		"5√2":     NewScalarFrac(50),
+		"0":       NewScalarFrac(0),
+	}
+
+	for expected, f := range tests {
+		res, err := SqrtFrac(f)
+		if err != nil {
+			t.Fatalf("Got error taking the square root of %v: %v", f, err)
+		}
+
+		if res.String() != expected {
+			t.Errorf("Square root of %v should be %s but was %v", f, expected, res)
+		}
+	}
+
+	if _, err := SqrtFrac(NewScalarFrac(-1)); err == nil {
+		t.Error("-1 must have no real square root!")
+	}
+}
@@ -0,0 +1,665 @@
+package matrix
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//Field is implemented by the elements of a number system that matrices can be built from.
+//Zero and One return the identities of the field the receiver belongs to, so elements which carry context
+//(such as a modulus) produce matching constants. Inv is never called on zero.
+type Field[T any] interface {
+	Add(T) T
+	Mul(T) T
+	Neg() T
+	Inv() T
+	IsZero() bool
+	Zero() T
+	One() T
+	String() string
+}
+
+//reducer is implemented by field elements which have a canonical form, such as fractions in lowest terms.
+type reducer[T any] interface {
+	Reduce() T
+}
+
+//normalize returns the canonical form of v if its type has one.
+func normalize[T any](v T) T {
+	if r, ok := any(v).(reducer[T]); ok {
+		return r.Reduce()
+	}
+
+	return v
+}
+
+//grid is the storage shared by every kind of matrix: its size and its entries in row order.
+//It knows nothing about arithmetic, which the helpers below take as functions of the entries.
+type grid[T any] struct {
+	//Number of rows and columns.
+	r, c int
+
+	//The values of the matrix, in row order.
+	values []T
+}
+
+//newGrid returns a grid of size r,c with every entry set to zero.
+func newGrid[T any](r, c int, zero T) grid[T] {
+	vals := make([]T, r*c)
+	for i := range vals {
+		vals[i] = zero
+	}
+
+	return grid[T]{r: r, c: c, values: vals}
+}
+
+//Rows returns the number of rows in the matrix.
+func (m grid[T]) Rows() int {
+	return m.r
+}
+
+//Cols returns the number of columns in the matrix.
+func (m grid[T]) Cols() int {
+	return m.c
+}
+
+//Get returns the value at the specified row and column.
+func (m grid[T]) Get(r, c int) T {
+	r, c = r-1, c-1
+	return m.values[r*m.c+c]
+}
+
+//Set sets the value at the specified row and column.
+func (m *grid[T]) Set(r, c int, v T) {
+	r, c = r-1, c-1
+	m.values[r*m.c+c] = v
+}
+
+func (m *grid[T]) switchRows(r1, r2 int) {
+	r1, r2 = r1-1, r2-1
+	for c := 0; c < m.c; c++ {
+		m.values[r1*m.c+c], m.values[r2*m.c+c] = m.values[r2*m.c+c], m.values[r1*m.c+c]
+	}
+}
+
+//String returns a string representation of the matrix.
+func (m grid[T]) String() string {
+	var buff bytes.Buffer
+
+	buff.WriteString("┌  ")
+	for c := 1; c < m.Cols(); c++ {
+		buff.WriteString(" \t")
+	}
+	buff.WriteString("  ┐\n")
+	for r := 1; r <= m.Rows(); r++ {
+		buff.WriteString("│ ")
+		buff.WriteString(fmt.Sprint(m.Get(r, 1)))
+		for c := 2; c <= m.Cols(); c++ {
+			buff.WriteString("\t")
+			buff.WriteString(fmt.Sprint(m.Get(r, c)))
+		}
+		buff.WriteString(" │\n")
+	}
+	buff.WriteString("└  ")
+	for c := 1; c < m.Cols(); c++ {
+		buff.WriteString(" \t")
+	}
+	buff.WriteString("  ┘")
+
+	return buff.String()
+}
+
+//copyGrid returns a copy of the grid, with same contents and size.
+func copyGrid[T any](m grid[T]) grid[T] {
+	mm := grid[T]{r: m.r, c: m.c, values: make([]T, len(m.values))}
+	copy(mm.values, m.values)
+
+	return mm
+}
+
+//equalGrid returns true if the grids have the same size and eq holds for each pair of entries.
+func equalGrid[T any](a, b grid[T], eq func(T, T) bool) bool {
+	if a.r != b.r || a.c != b.c {
+		return false
+	}
+
+	for i := range a.values {
+		if !eq(a.values[i], b.values[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+//transposeGrid returns the transpose of the grid.
+func transposeGrid[T any](m grid[T]) grid[T] {
+	rm := grid[T]{r: m.c, c: m.r, values: make([]T, len(m.values))}
+
+	for r := 1; r <= m.r; r++ {
+		for c := 1; c <= m.c; c++ {
+			rm.Set(c, r, m.Get(r, c))
+		}
+	}
+
+	return rm
+}
+
+//augmentGrid returns the grid with the columns of a followed by those of b.
+//It returns an error if the two grids do not have the same number of rows.
+func augmentGrid[T any](a, b grid[T]) (grid[T], error) {
+	if a.r != b.r {
+		return a, errors.New("augmented matrices must have equal row counts")
+	}
+
+	rm := grid[T]{r: a.r, c: a.c + b.c, values: make([]T, a.r*(a.c+b.c))}
+
+	for r := 1; r <= a.Rows(); r++ {
+		for c := 1; c <= a.Cols(); c++ {
+			rm.Set(r, c, a.Get(r, c))
+		}
+
+		for c := 1; c <= b.Cols(); c++ {
+			rm.Set(r, a.Cols()+c, b.Get(r, c))
+		}
+	}
+
+	return rm, nil
+}
+
+//mapGrid returns the grid whose entries are f applied to the entries of m.
+func mapGrid[T any](m grid[T], f func(T) T) grid[T] {
+	rm := grid[T]{r: m.r, c: m.c, values: make([]T, len(m.values))}
+
+	for i, v := range m.values {
+		rm.values[i] = f(v)
+	}
+
+	return rm
+}
+
+//addGrid returns the entrywise sum of a and b under add.
+//It returns an error if a and b are not the same size.
+func addGrid[T any](a, b grid[T], add func(T, T) T) (grid[T], error) {
+	if a.r != b.r || a.c != b.c {
+		return a, errors.New("addition requires two identically-sized matrices")
+	}
+
+	rm := grid[T]{r: a.r, c: a.c, values: make([]T, len(a.values))}
+
+	for i := range rm.values {
+		rm.values[i] = add(a.values[i], b.values[i])
+	}
+
+	return rm, nil
+}
+
+//multiplyGrid returns the matrix product of a and b under add and mul, with sums starting from zero.
+//If the grids cannot be multiplied, an error is returned.
+func multiplyGrid[T any](a, b grid[T], zero T, add, mul func(T, T) T) (grid[T], error) {
+	if a.c != b.r {
+		return a, errors.New("multiplication can only be done on matrices A and B if the number of columns of A equals the number of rows of B")
+	}
+
+	rm := newGrid(a.r, b.c, zero)
+
+	for r := 1; r <= rm.Rows(); r++ {
+		for c := 1; c <= rm.Cols(); c++ {
+			sum := zero
+			for count := 1; count <= a.c; count++ {
+				sum = add(sum, mul(a.Get(r, count), b.Get(count, c)))
+			}
+			rm.Set(r, c, sum)
+		}
+	}
+
+	return rm, nil
+}
+
+//Mat represents a matrix whose entries are elements of a field.
+type Mat[T Field[T]] struct {
+	grid[T]
+
+	//The zero of the field, which also gives access to its one.
+	zero T
+}
+
+//NewMat returns a zero matrix of size r,c whose entries belong to the same field as zero.
+func NewMat[T Field[T]](r, c int, zero T) Mat[T] {
+	return Mat[T]{grid: newGrid(r, c, zero), zero: zero}
+}
+
+//IdentityMat returns the identity matrix of size i whose entries belong to the same field as zero.
+func IdentityMat[T Field[T]](i int, zero T) Mat[T] {
+	rm := NewMat(i, i, zero)
+
+	for d := 1; d <= i; d++ {
+		rm.Set(d, d, zero.One())
+	}
+
+	return rm
+}
+
+//Equals returns true if the two matrices are equivalent, and false otherwise.
+func (m Mat[T]) Equals(m1 Mat[T]) bool {
+	return equalGrid(m.grid, m1.grid, func(a, b T) bool {
+		return a.Add(b.Neg()).IsZero()
+	})
+}
+
+//Set sets the value at the specified row and column to the given element.
+func (m *Mat[T]) Set(r, c int, v T) {
+	m.grid.Set(r, c, normalize(v))
+}
+
+//SwitchRows switches two rows. It is an elementary row operation.
+func (m *Mat[T]) SwitchRows(r1, r2 int) {
+	m.switchRows(r1, r2)
+}
+
+//MultiplyRow multiplies row r by the scalar s. It is an elementary row operation.
+func (m *Mat[T]) MultiplyRow(r int, s T) {
+	r = r - 1
+	for c := 0; c < m.c; c++ {
+		m.values[r*m.c+c] = normalize(m.values[r*m.c+c].Mul(s))
+	}
+}
+
+//MultiplyAndAddRow adds row r1 multiplied by scalar s to row r2. It is an elementary row operation.
+func (m *Mat[T]) MultiplyAndAddRow(r1 int, s T, r2 int) {
+	r1, r2 = r1-1, r2-1
+	for c := 0; c < m.c; c++ {
+		m.values[r2*m.c+c] = normalize(m.values[r2*m.c+c].Add(m.values[r1*m.c+c].Mul(s)))
+	}
+}
+
+//CopyMat creates a copy of the matrix, with same contents and size.
+func CopyMat[T Field[T]](m Mat[T]) Mat[T] {
+	return Mat[T]{grid: copyGrid(m.grid), zero: m.zero}
+}
+
+//TransposeMat takes a copy of a matrix and returns its transpose.
+func TransposeMat[T Field[T]](m Mat[T]) Mat[T] {
+	return Mat[T]{grid: transposeGrid(m.grid), zero: m.zero}
+}
+
+func isLeadingEntry[T Field[T]](m Mat[T], r, c int) bool {
+	if m.Get(r, c).IsZero() {
+		return false // must not be zero to be a leading entry
+	}
+
+	for cc := c - 1; cc > 0; cc-- {
+		if !m.Get(r, cc).IsZero() {
+			return false
+		}
+	}
+
+	return true
+}
+
+//RowOpKind identifies one of the three elementary row operations.
+type RowOpKind int
+
+//RowOpKind definitions
+const (
+	SwitchOp RowOpKind = iota // switch rows R1 and R2
+	ScaleOp                   // multiply row R1 by Scalar
+	AddOp                     // add Scalar times row R1 to row R2
+)
+
+//RowOp is an elementary row operation on a matrix with entries of type T.
+type RowOp[T Field[T]] struct {
+	Kind   RowOpKind
+	R1, R2 int
+	Scalar T
+}
+
+//Apply performs the row operation on the matrix.
+func (m *Mat[T]) Apply(op RowOp[T]) {
+	switch op.Kind {
+	case SwitchOp:
+		m.SwitchRows(op.R1, op.R2)
+	case ScaleOp:
+		m.MultiplyRow(op.R1, op.Scalar)
+	case AddOp:
+		m.MultiplyAndAddRow(op.R1, op.Scalar, op.R2)
+	}
+}
+
+//Inverse returns the row operation which undoes op.
+func (op RowOp[T]) Inverse() RowOp[T] {
+	switch op.Kind {
+	case ScaleOp:
+		op.Scalar = op.Scalar.Inv()
+	case AddOp:
+		op.Scalar = op.Scalar.Neg()
+	}
+
+	return op
+}
+
+//Elementary returns the elementary matrix of size n which performs the row operation when multiplied on the left.
+func (op RowOp[T]) Elementary(n int) Mat[T] {
+	m := IdentityMat(n, op.Scalar.Zero())
+	m.Apply(op)
+
+	return m
+}
+
+//String returns a string representation of the row operation, such as "R1 ↔ R2", "R2 → (1/2)R2" or "R3 → R3 + (-2)R1".
+func (op RowOp[T]) String() string {
+	scalar := op.Scalar.String()
+	if strings.ContainsAny(scalar, "/- ") {
+		scalar = "(" + scalar + ")"
+	}
+
+	switch op.Kind {
+	case SwitchOp:
+		return fmt.Sprintf("R%d ↔ R%d", op.R1, op.R2)
+	case ScaleOp:
+		return fmt.Sprintf("R%d → %sR%d", op.R1, scalar, op.R1)
+	}
+
+	return fmt.Sprintf("R%d → R%d + %sR%d", op.R2, op.R2, scalar, op.R1)
+}
+
+//rowOpRecorder applies row operations to a matrix, keeping a record of every one which changes it.
+type rowOpRecorder[T Field[T]] struct {
+	m   *Mat[T]
+	ops []RowOp[T]
+}
+
+func (rec *rowOpRecorder[T]) apply(op RowOp[T]) {
+	switch op.Kind {
+	case SwitchOp:
+		if op.R1 == op.R2 {
+			return
+		}
+	case ScaleOp:
+		if op.Scalar.Add(op.Scalar.One().Neg()).IsZero() {
+			return
+		}
+	case AddOp:
+		if op.Scalar.IsZero() {
+			return
+		}
+	}
+
+	rec.m.Apply(op)
+	rec.ops = append(rec.ops, op)
+}
+
+//RefMat takes a copy of a matrix and returns itself in row echelon form.
+func RefMat[T Field[T]](m Mat[T]) Mat[T] {
+	m, _ = RefOps(m)
+	return m
+}
+
+//RefOps takes a copy of a matrix and returns it in row echelon form, along with the row operations which were used, in order.
+func RefOps[T Field[T]](m Mat[T]) (Mat[T], []RowOp[T]) {
+	m = CopyMat(m)
+	rec := rowOpRecorder[T]{m: &m}
+
+	startr := 1
+	for c := 1; c <= m.Cols(); c++ { // find a leading entry in this column
+		found := false
+		for r := startr; r <= m.Rows(); r++ {
+			if isLeadingEntry(m, r, c) {
+				found = true
+				rec.apply(RowOp[T]{Kind: SwitchOp, R1: startr, R2: r}) // move it to the top, son!
+				break
+			}
+		}
+
+		if !found { // no leading entry, next column please
+			continue
+		}
+
+		rec.apply(RowOp[T]{Kind: ScaleOp, R1: startr, Scalar: m.Get(startr, c).Inv()}) // make first entry one
+
+		for r := startr + 1; r <= m.Rows(); r++ {
+			if isLeadingEntry(m, r, c) {
+				rec.apply(RowOp[T]{Kind: AddOp, R1: startr, R2: r, Scalar: m.Get(startr, c).Inv().Mul(m.Get(r, c).Neg())}) // zero first column entry
+			}
+		}
+
+		startr++ // row is now in ref
+	}
+
+	return m, rec.ops
+}
+
+//RrefMat takes a copy of a matrix and returns it in reduced row echelon form.
+func RrefMat[T Field[T]](m Mat[T]) Mat[T] {
+	m, _ = RrefOps(m)
+	return m
+}
+
+//RrefOps takes a copy of a matrix and returns it in reduced row echelon form, along with the row operations which were used, in order.
+func RrefOps[T Field[T]](m Mat[T]) (Mat[T], []RowOp[T]) {
+	m, ops := RefOps(m)
+	rec := rowOpRecorder[T]{m: &m, ops: ops}
+
+	for c := 1; c <= m.Cols(); c++ {
+		for r := 1; r <= m.Rows(); r++ {
+			if isLeadingEntry(m, r, c) {
+				rec.apply(RowOp[T]{Kind: ScaleOp, R1: r, Scalar: m.Get(r, c).Inv()}) // make the leading entry 1
+				for rr := r - 1; rr > 0; rr-- {                                      // for each row above the current row...
+					if !m.Get(rr, c).IsZero() {
+						rec.apply(RowOp[T]{Kind: AddOp, R1: r, R2: rr, Scalar: m.Get(rr, c).Neg().Mul(m.Get(r, c).Inv())}) // clear entry above leading entry
+					}
+				}
+			}
+		}
+	}
+
+	return m, rec.ops
+}
+
+//InverseMat takes a copy of a matrix and returns its inverse.
+//An error is returned if the matrix has no inverse.
+func InverseMat[T Field[T]](m Mat[T]) (Mat[T], error) {
+	if m.Rows() != m.Cols() {
+		return m, errors.New("non-square matrices have no inverse")
+	}
+
+	aug, _ := AugmentMat(m, IdentityMat(m.r, m.zero)) // ignore error because the identity will always match m row size
+
+	aug = RrefMat(aug)
+
+	one := m.zero.One()
+	for d := 1; d <= m.Rows(); d++ { // the left half must have become the identity
+		if !aug.Get(d, d).Add(one.Neg()).IsZero() {
+			return m, errors.New("matrix has no inverse")
+		}
+	}
+
+	rm := NewMat(m.r, m.r, m.zero)
+
+	for r := 1; r <= rm.Rows(); r++ {
+		for c := 1; c <= rm.Cols(); c++ {
+			rm.Set(r, c, aug.Get(r, rm.Cols()+c))
+		}
+	}
+
+	return rm, nil
+}
+
+//DeterminantMat returns the determinant of a matrix.
+//An error is returned if the matrix is not square.
+func DeterminantMat[T Field[T]](m Mat[T]) (T, error) {
+	if m.Rows() != m.Cols() {
+		return m.zero, errors.New("non-square matrices have no determinant")
+	}
+
+	m = CopyMat(m)
+
+	det := m.zero.One()
+
+	for c := 1; c <= m.Cols(); c++ {
+		pivot := 0
+		for r := c; r <= m.Rows(); r++ {
+			if !m.Get(r, c).IsZero() {
+				pivot = r
+				break
+			}
+		}
+
+		if pivot == 0 { // no pivot in this column, so the matrix is singular
+			return m.zero, nil
+		}
+
+		if pivot != c {
+			m.SwitchRows(c, pivot)
+			det = det.Neg() // swapping rows flips the sign
+		}
+
+		det = normalize(det.Mul(m.Get(c, c)))
+
+		inv := m.Get(c, c).Inv()
+		for r := c + 1; r <= m.Rows(); r++ {
+			if !m.Get(r, c).IsZero() {
+				m.MultiplyAndAddRow(c, m.Get(r, c).Neg().Mul(inv), r)
+			}
+		}
+	}
+
+	return det, nil
+}
+
+//NullSpaceMat returns a matrix whose columns form a basis for the null space of m.
+//If the null space is trivial, the zero vector is returned.
+func NullSpaceMat[T Field[T]](m Mat[T]) Mat[T] {
+	m = RrefMat(m)
+
+	isZero := func(v T) bool {
+		return v.IsZero()
+	}
+
+	neg := func(v T) T {
+		return v.Neg()
+	}
+
+	return Mat[T]{grid: nullSpaceFromRref(m.grid, m.zero, m.zero.One(), isZero, neg), zero: m.zero}
+}
+
+//nullSpaceFromRref builds a null space basis from a grid already in reduced row echelon form, so that the first nonzero
+//entry of each row is its leading one. Each free column contributes one basis vector.
+func nullSpaceFromRref[T any](m grid[T], zero, one T, isZero func(T) bool, neg func(T) T) grid[T] {
+	pivots := make([]int, m.Cols()+1) // pivots[c] is the row with a leading entry in column c, or 0
+	for r := 1; r <= m.Rows(); r++ {
+		for c := 1; c <= m.Cols(); c++ {
+			if !isZero(m.Get(r, c)) {
+				pivots[c] = r
+				break
+			}
+		}
+	}
+
+	free := []int{}
+	for c := 1; c <= m.Cols(); c++ {
+		if pivots[c] == 0 {
+			free = append(free, c)
+		}
+	}
+
+	if len(free) == 0 {
+		return newGrid(m.Cols(), 1, zero)
+	}
+
+	rm := newGrid(m.Cols(), len(free), zero)
+
+	for i, fc := range free {
+		rm.Set(fc, i+1, one)
+		for c := 1; c <= m.Cols(); c++ {
+			if pivots[c] != 0 {
+				rm.Set(c, i+1, neg(m.Get(pivots[c], fc)))
+			}
+		}
+	}
+
+	return rm
+}
+
+//AugmentMat augments a with b then returns this matrix.
+//It returns an error if the two matrices do not have the same number of rows.
+func AugmentMat[T Field[T]](a, b Mat[T]) (Mat[T], error) {
+	g, err := augmentGrid(a.grid, b.grid)
+	if err != nil {
+		return a, err
+	}
+
+	return Mat[T]{grid: g, zero: a.zero}, nil
+}
+
+//AddMat adds matrix a to matrix b.
+//It returns an error if a and b are not the same size.
+func AddMat[T Field[T]](a, b Mat[T]) (Mat[T], error) {
+	g, err := addGrid(a.grid, b.grid, func(x, y T) T {
+		return normalize(x.Add(y))
+	})
+	if err != nil {
+		return a, err
+	}
+
+	return Mat[T]{grid: g, zero: a.zero}, nil
+}
+
+//ScaleMat multiplies every entry of the matrix by s and returns this new matrix.
+func ScaleMat[T Field[T]](s T, m Mat[T]) Mat[T] {
+	g := mapGrid(m.grid, func(v T) T {
+		return normalize(v.Mul(s))
+	})
+
+	return Mat[T]{grid: g, zero: m.zero}
+}
+
+//MultiplyMat multiplies a by b. If the matrices cannot be multiplied, an error is returned.
+func MultiplyMat[T Field[T]](a, b Mat[T]) (Mat[T], error) {
+	add := func(x, y T) T {
+		return normalize(x.Add(y))
+	}
+
+	mul := func(x, y T) T {
+		return x.Mul(y)
+	}
+
+	g, err := multiplyGrid(a.grid, b.grid, a.zero, add, mul)
+	if err != nil {
+		return a, err
+	}
+
+	return Mat[T]{grid: g, zero: a.zero}, nil
+}
+
+//PowerMat raises a square matrix to the integer power k by repeated squaring.
+//Negative powers are powers of the inverse. An error is returned if the matrix is not square,
+//or if k is negative and the matrix has no inverse.
+func PowerMat[T Field[T]](m Mat[T], k int) (Mat[T], error) {
+	if m.Rows() != m.Cols() {
+		return m, errors.New("only square matrices can be raised to a power")
+	}
+
+	if k < 0 {
+		inv, err := InverseMat(m)
+		if err != nil {
+			return m, err
+		}
+
+		m, k = inv, -k
+	}
+
+	rm := IdentityMat(m.r, m.zero)
+
+	for ; k > 0; k /= 2 {
+		if k%2 == 1 {
+			rm, _ = MultiplyMat(rm, m)
+		}
+
+		m, _ = MultiplyMat(m, m)
+	}
+
+	return rm, nil
+}
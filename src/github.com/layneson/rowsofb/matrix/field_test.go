@@ -0,0 +1,78 @@
+package matrix
+
+import "testing"
+
+func manualModMat(p int, mat [][]int) Mat[ModInt] {
+	m := NewMat(len(mat), len(mat[0]), NewModInt(0, p))
+
+	for r := range mat {
+		for c := range mat[r] {
+			m.Set(r+1, c+1, NewModInt(mat[r][c], p))
+		}
+	}
+
+	return m
+}
+
+func TestModIntField(t *testing.T) {
+	a, b := NewModInt(3, 7), NewModInt(-2, 7)
+
+	if a.Add(b).Value() != 1 {
+		t.Errorf("3 + -2 modulo 7 should be 1 but was %v", a.Add(b))
+	}
+
+	if a.Mul(b).Value() != 1 {
+		t.Errorf("3 * -2 modulo 7 should be 1 but was %v", a.Mul(b))
+	}
+
+	if a.Inv().Value() != 5 {
+		t.Errorf("Inverse of 3 modulo 7 should be 5 but was %v", a.Inv())
+	}
+
+	if !a.Add(a.Neg()).IsZero() {
+		t.Errorf("3 + -3 modulo 7 should be zero")
+	}
+}
+
+func TestRrefMat(t *testing.T) {
+	input := manualModMat(3, [][]int{
+		{1, 1, 0},
+		{1, 2, 1},
+	})
+
+	expected := manualModMat(3, [][]int{
+		{1, 0, 2},
+		{0, 1, 1},
+	})
+
+	if res := RrefMat(input); !res.Equals(expected) {
+		t.Errorf("Incorrect row reduction over GF(3)! Wanted\n %v but got\n %v", expected, res)
+	}
+}
+
+func TestInverseMat(t *testing.T) {
+	input := manualModMat(5, [][]int{
+		{2, 1},
+		{1, 1},
+	})
+
+	res, err := InverseMat(input)
+	if err != nil {
+		t.Fatalf("Got error during inverse calculation over GF(5): %v", err)
+	}
+
+	product, _ := MultiplyMat(input, res)
+
+	if !product.Equals(IdentityMat(2, NewModInt(0, 5))) {
+		t.Errorf("Incorrect inverse over GF(5)! Got\n %v", res)
+	}
+
+	singular := manualModMat(5, [][]int{
+		{1, 2},
+		{3, 1},
+	})
+
+	if _, err := InverseMat(singular); err == nil {
+		t.Error("Matrix must have no inverse over GF(5)!")
+	}
+}
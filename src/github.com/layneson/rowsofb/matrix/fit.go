@@ -0,0 +1,91 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+)
+
+//Interpolate returns the polynomial of least degree passing through the points (xs[i], ys[i]).
+//It solves the Vandermonde system for the coefficients by row reduction.
+//An error is returned if two points share an x value.
+func Interpolate(xs, ys []Frac) (Poly, error) {
+	if err := checkPoints(xs, ys); err != nil {
+		return Poly{}, err
+	}
+
+	aug, _ := Augment(Vandermonde(xs), columnVector(ys))
+	aug = Rref(aug)
+
+	coeffs := make([]Frac, len(xs))
+	for i := range coeffs {
+		coeffs[i] = aug.Get(i+1, len(xs)+1)
+	}
+
+	return NewPoly(coeffs...), nil
+}
+
+//PolyFit returns the polynomial of at most the given degree which best fits the points (xs[i], ys[i]) in the least-squares sense,
+//along with its sum of squared residuals. The coefficients solve the normal equations VᵀVc = Vᵀy, where row i of V holds the powers of xs[i].
+//An error is returned if there are fewer distinct x values than coefficients, as the fit is then not unique.
+func PolyFit(xs, ys []Frac, degree int) (Poly, Frac, error) {
+	if len(xs) != len(ys) {
+		return Poly{}, Frac{}, errors.New("every point needs both an x and a y value")
+	}
+
+	if degree < 0 {
+		return Poly{}, Frac{}, errors.New("the degree must not be negative")
+	}
+
+	v := powerColumns(xs, degree+1)
+	vt := Transpose(v)
+
+	normal, _ := Multiply(vt, v)
+	inv, err := Inverse(normal)
+	if err != nil {
+		return Poly{}, Frac{}, fmt.Errorf("a fit of degree %d needs at least %d distinct x values", degree, degree+1)
+	}
+
+	y := columnVector(ys)
+	vty, _ := Multiply(vt, y)
+	c, _ := Multiply(inv, vty)
+
+	coeffs := make([]Frac, degree+1)
+	for i := range coeffs {
+		coeffs[i] = c.Get(i+1, 1)
+	}
+
+	fit, _ := Multiply(v, c)
+	residual, _ := Add(y, Scale(NewScalarFrac(-1), fit))
+	sse, _ := Multiply(Transpose(residual), residual)
+
+	return NewPoly(coeffs...), sse.Get(1, 1).Reduce(), nil
+}
+
+func checkPoints(xs, ys []Frac) error {
+	if len(xs) != len(ys) {
+		return errors.New("every point needs both an x and a y value")
+	}
+
+	if len(xs) == 0 {
+		return errors.New("at least one point is needed")
+	}
+
+	for i := range xs {
+		for j := 0; j < i; j++ {
+			if xs[i].Equals(xs[j]) {
+				return fmt.Errorf("points %d and %d share the x value %v", j+1, i+1, xs[i])
+			}
+		}
+	}
+
+	return nil
+}
+
+func columnVector(v []Frac) M {
+	rm := New(len(v), 1)
+	for i, f := range v {
+		rm.Set(i+1, 1, f)
+	}
+
+	return rm
+}
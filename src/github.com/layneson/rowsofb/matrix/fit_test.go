@@ -0,0 +1,45 @@
+package matrix
+
+import "testing"
+
+func TestInterpolate(t *testing.T) {
+	//y = x² - 2x + 3 through x = 0, 1, 2
+	p, err := Interpolate(fracs(0, 1, 2), fracs(3, 2, 3))
+	if err != nil {
+		t.Fatalf("Got error during interpolation: %v", err)
+	}
+
+	if expected := manualPoly(3, -2, 1); !p.Equals(expected) {
+		t.Errorf("Incorrect interpolating polynomial! Wanted %v but got %v", expected, p)
+	}
+
+	if _, err := Interpolate(fracs(1, 1), fracs(2, 3)); err == nil {
+		t.Error("Points sharing an x value must be rejected!")
+	}
+}
+
+func TestPolyFit(t *testing.T) {
+	//The least-squares line through (0, 0), (1, 1), (2, 1) is y = x/2 + 1/6.
+	p, sse, err := PolyFit(fracs(0, 1, 2), fracs(0, 1, 1), 1)
+	if err != nil {
+		t.Fatalf("Got error during fitting: %v", err)
+	}
+
+	if expected := NewPoly(NewFrac(1, 6), NewFrac(1, 2)); !p.Equals(expected) {
+		t.Errorf("Incorrect least-squares line! Wanted %v but got %v", expected, p)
+	}
+
+	if !sse.Equals(NewFrac(1, 6)) {
+		t.Errorf("Sum of squared residuals should be 1/6 but was %v", sse)
+	}
+
+	//A fit of full degree interpolates exactly.
+	p, sse, _ = PolyFit(fracs(0, 1, 2), fracs(3, 2, 3), 2)
+	if !p.Equals(manualPoly(3, -2, 1)) || !sse.IsZero() {
+		t.Errorf("A fit of full degree should interpolate, but got %v with residual %v", p, sse)
+	}
+
+	if _, _, err := PolyFit(fracs(1, 1, 1), fracs(1, 2, 3), 1); err == nil {
+		t.Error("A line through points with a single x value must be rejected!")
+	}
+}
@@ -0,0 +1,283 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+//FM represents a matrix of floating-point numbers.
+type FM struct {
+	grid[float64]
+}
+
+//NewFM returns a zero floating-point matrix of size r,c.
+func NewFM(r, c int) FM {
+	return FM{newGrid(r, c, 0.0)}
+}
+
+//FMFromMatrix returns a floating-point approximation of a matrix of fractions.
+func FMFromMatrix(m M) FM {
+	fm := NewFM(m.Rows(), m.Cols())
+
+	for r := 1; r <= m.Rows(); r++ {
+		for c := 1; c <= m.Cols(); c++ {
+			fm.Set(r, c, m.Get(r, c).Float())
+		}
+	}
+
+	return fm
+}
+
+//IdentityFM returns the floating-point identity matrix of size i.
+func IdentityFM(i int) FM {
+	return FMFromMatrix(Identity(i))
+}
+
+//Float returns the floating-point value closest to the fraction.
+func (f Frac) Float() float64 {
+	return float64(f.n) / float64(f.d)
+}
+
+//NearestFrac returns the fraction with the smallest denominator within tol of x.
+//It walks the continued fraction expansion of x, capping the denominator to keep it from overflowing.
+//x must be finite.
+func NearestFrac(x, tol float64) Frac {
+	const maxDen = 1e9
+
+	sign := 1
+	if x < 0 {
+		sign, x = -1, -x
+	}
+
+	//Convergents h/k of the continued fraction of x.
+	h, hprev := 1.0, 0.0
+	k, kprev := 0.0, 1.0
+
+	rem := x
+	for {
+		a := math.Floor(rem)
+		if a*k+kprev > maxDen {
+			break
+		}
+
+		h, hprev = a*h+hprev, h
+		k, kprev = a*k+kprev, k
+
+		if math.Abs(x-h/k) <= tol || rem == a {
+			break
+		}
+
+		rem = 1 / (rem - a)
+	}
+
+	return NewFrac(sign*int(h), int(k)).Reduce()
+}
+
+//NearestMatrix returns the matrix of fractions nearest to m, converting each entry with NearestFrac.
+func NearestMatrix(m FM, tol float64) M {
+	rm := New(m.r, m.c)
+
+	for r := 1; r <= m.Rows(); r++ {
+		for c := 1; c <= m.Cols(); c++ {
+			rm.Set(r, c, NearestFrac(m.Get(r, c), tol))
+		}
+	}
+
+	return rm
+}
+
+//CopyFM creates a copy of the matrix, with same contents and size.
+func CopyFM(m FM) FM {
+	return FM{copyGrid(m.grid)}
+}
+
+//TransposeFM takes a copy of a matrix and returns its transpose.
+func TransposeFM(m FM) FM {
+	return FM{transposeGrid(m.grid)}
+}
+
+//AddFM adds matrix a to matrix b.
+//It returns an error if a and b are not the same size.
+func AddFM(a, b FM) (FM, error) {
+	g, err := addGrid(a.grid, b.grid, func(x, y float64) float64 {
+		return x + y
+	})
+
+	return FM{g}, err
+}
+
+//ScaleFM multiplies every entry of the matrix by s.
+func ScaleFM(s float64, m FM) FM {
+	return FM{mapGrid(m.grid, func(v float64) float64 {
+		return v * s
+	})}
+}
+
+//MultiplyFM multiplies a by b. If the matrices cannot be multiplied, an error is returned.
+func MultiplyFM(a, b FM) (FM, error) {
+	add := func(x, y float64) float64 {
+		return x + y
+	}
+
+	mul := func(x, y float64) float64 {
+		return x * y
+	}
+
+	g, err := multiplyGrid(a.grid, b.grid, 0, add, mul)
+
+	return FM{g}, err
+}
+
+//AugmentFM augments a with b then returns this matrix.
+//It returns an error if the two matrices do not have the same number of rows.
+func AugmentFM(a, b FM) (FM, error) {
+	g, err := augmentGrid(a.grid, b.grid)
+
+	return FM{g}, err
+}
+
+func (m *FM) multiplyRow(r int, s float64) {
+	for c := 1; c <= m.c; c++ {
+		m.Set(r, c, m.Get(r, c)*s)
+	}
+}
+
+func (m *FM) multiplyAndAddRow(r1 int, s float64, r2 int) {
+	for c := 1; c <= m.c; c++ {
+		m.Set(r2, c, m.Get(r2, c)+m.Get(r1, c)*s)
+	}
+}
+
+//clean sets every entry within tol of zero to exactly zero.
+func (m *FM) clean(tol float64) {
+	for i, v := range m.values {
+		if math.Abs(v) <= tol {
+			m.values[i] = 0
+		}
+	}
+}
+
+//RefFM takes a copy of a matrix and returns it in row echelon form using partial pivoting:
+//the pivot of each column is the remaining entry of largest magnitude.
+//Entries within tol of zero are treated as zero.
+func RefFM(m FM, tol float64) FM {
+	m, _ = eliminateFM(m, tol, false)
+	return m
+}
+
+//RrefFM takes a copy of a matrix and returns it in reduced row echelon form using partial pivoting.
+//Entries within tol of zero are treated as zero.
+func RrefFM(m FM, tol float64) FM {
+	m, _ = eliminateFM(m, tol, true)
+	return m
+}
+
+//eliminateFM performs Gaussian elimination with partial pivoting, clearing entries above the pivots as well if reduced is set.
+//It also returns the determinant of the leading square part of the matrix, assuming it is square.
+func eliminateFM(m FM, tol float64, reduced bool) (FM, float64) {
+	m = CopyFM(m)
+	m.clean(tol)
+
+	det := 1.0
+
+	startr := 1
+	for c := 1; c <= m.Cols() && startr <= m.Rows(); c++ {
+		pivot := startr
+		for r := startr + 1; r <= m.Rows(); r++ {
+			if math.Abs(m.Get(r, c)) > math.Abs(m.Get(pivot, c)) {
+				pivot = r
+			}
+		}
+
+		if math.Abs(m.Get(pivot, c)) <= tol { // no usable pivot, next column please
+			for r := startr; r <= m.Rows(); r++ {
+				m.Set(r, c, 0)
+			}
+			det = 0
+			continue
+		}
+
+		if pivot != startr {
+			m.switchRows(startr, pivot)
+			det = -det
+		}
+
+		det *= m.Get(startr, c)
+		m.multiplyRow(startr, 1/m.Get(startr, c))
+		m.Set(startr, c, 1)
+
+		firstr := startr + 1
+		if reduced {
+			firstr = 1
+		}
+
+		for r := firstr; r <= m.Rows(); r++ {
+			if r != startr && m.Get(r, c) != 0 {
+				m.multiplyAndAddRow(startr, -m.Get(r, c), r)
+				m.Set(r, c, 0)
+			}
+		}
+
+		m.clean(tol)
+
+		startr++
+	}
+
+	if startr <= m.Rows() {
+		det = 0
+	}
+
+	return m, det
+}
+
+//DeterminantFM returns the determinant of a floating-point matrix, computed with partial pivoting.
+//An error is returned if the matrix is not square.
+func DeterminantFM(m FM, tol float64) (float64, error) {
+	if m.Rows() != m.Cols() {
+		return 0, errors.New("non-square matrices have no determinant")
+	}
+
+	_, det := eliminateFM(m, tol, false)
+
+	return det, nil
+}
+
+//InverseFM takes a copy of a matrix and returns its inverse, computed with partial pivoting.
+//An error is returned if the matrix has no inverse within the tolerance.
+func InverseFM(m FM, tol float64) (FM, error) {
+	if m.Rows() != m.Cols() {
+		return m, errors.New("non-square matrices have no inverse")
+	}
+
+	aug, _ := AugmentFM(m, IdentityFM(m.r))
+	aug = RrefFM(aug, tol)
+
+	rm := NewFM(m.r, m.r)
+	for r := 1; r <= m.Rows(); r++ {
+		if aug.Get(r, r) != 1 {
+			return m, errors.New("matrix has no inverse")
+		}
+
+		for c := 1; c <= m.Cols(); c++ {
+			rm.Set(r, c, aug.Get(r, m.c+c))
+		}
+	}
+
+	return rm, nil
+}
+
+//NullSpaceFM returns a matrix whose columns form a basis for the null space of m, computed with partial pivoting.
+//If the null space is trivial, the zero vector is returned.
+func NullSpaceFM(m FM, tol float64) FM {
+	m = RrefFM(m, tol)
+
+	isZero := func(v float64) bool {
+		return v == 0
+	}
+
+	neg := func(v float64) float64 {
+		return -v
+	}
+
+	return FM{nullSpaceFromRref(m.grid, 0, 1, isZero, neg)}
+}
@@ -0,0 +1,108 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+func fmEquals(m1, m2 FM, tol float64) bool {
+	if m1.r != m2.r || m1.c != m2.c {
+		return false
+	}
+
+	for i := range m1.values {
+		if math.Abs(m1.values[i]-m2.values[i]) > tol {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestNearestFrac(t *testing.T) {
+	tests := []struct {
+		x float64
+		f Frac
+	}{
+		{1.0 / 3.0, NewFrac(1, 3)},
+		{-0.75, NewFrac(-3, 4)},
+		{151.0 / 2.0, NewFrac(151, 2)},
+		{5, NewScalarFrac(5)},
+		{0, NewScalarFrac(0)},
+		{math.Pi, NewFrac(312689, 99532)},
+	}
+
+	for _, tst := range tests {
+		res := NearestFrac(tst.x, 1e-10)
+		if !fractionEquals(res, tst.f) {
+			t.Errorf("Nearest fraction to %v should be %v but was %v", tst.x, tst.f, res)
+		}
+	}
+
+	if res := NearestFrac(math.Pi, 1e-2); !fractionEquals(res, NewFrac(22, 7)) {
+		t.Errorf("Nearest fraction to pi within 0.01 should be 22/7 but was %v", res)
+	}
+}
+
+func TestRrefFM(t *testing.T) {
+	input := FMFromMatrix(manualMatrix([][]string{
+		{"-12", "2", "-6"},
+		{"18", "-3", "9"},
+		{"-2", "1/3", "-1"},
+	}))
+
+	expected := FMFromMatrix(manualMatrix([][]string{
+		{"1", "-1/6", "1/2"},
+		{"0", "0", "0"},
+		{"0", "0", "0"},
+	}))
+
+	res := RrefFM(input, 1e-10)
+	if !fmEquals(res, expected, 1e-12) {
+		t.Errorf("Incorrect floating-point row reduction result! Wanted\n %v but got\n %v", expected, res)
+	}
+}
+
+func TestInverseFM(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"2", "6", "8"},
+		{"6", "18", "25"},
+		{"6", "17", "32"},
+	})
+
+	exact, _ := Inverse(input)
+
+	res, err := InverseFM(FMFromMatrix(input), 1e-10)
+	if err != nil {
+		t.Fatalf("Got error during floating-point inverse calculation: %v", err)
+	}
+
+	if !fmEquals(res, FMFromMatrix(exact), 1e-9) {
+		t.Errorf("Incorrect floating-point inverse! Wanted\n %v but got\n %v", exact, res)
+	}
+
+	if !matrixEquals(NearestMatrix(res, 1e-9), exact) {
+		t.Errorf("Floating-point inverse should convert back to\n %v", exact)
+	}
+
+	if _, err := InverseFM(FMFromMatrix(manualMatrix([][]string{{"1", "2"}, {"2", "4"}})), 1e-10); err == nil {
+		t.Error("Singular matrix must have no inverse!")
+	}
+}
+
+func TestDeterminantFM(t *testing.T) {
+	input := FMFromMatrix(manualMatrix([][]string{
+		{"2", "6", "8"},
+		{"6", "18", "25"},
+		{"6", "17", "32"},
+	}))
+
+	det, err := DeterminantFM(input, 1e-10)
+	if err != nil {
+		t.Fatalf("Got error during floating-point determinant calculation: %v", err)
+	}
+
+	if math.Abs(det-2) > 1e-9 {
+		t.Errorf("Determinant should be 2 but was %v", det)
+	}
+}
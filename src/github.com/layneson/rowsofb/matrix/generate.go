@@ -0,0 +1,146 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+)
+
+//VectorEntries returns the entries of a row or column vector in order.
+//It returns an error if the matrix has more than one row and more than one column.
+func VectorEntries(m M) ([]Frac, error) {
+	if m.Rows() != 1 && m.Cols() != 1 {
+		return nil, fmt.Errorf("expected a row or column vector but was supplied a %dx%d matrix", m.Rows(), m.Cols())
+	}
+
+	v := []Frac{}
+	for r := 1; r <= m.Rows(); r++ {
+		for c := 1; c <= m.Cols(); c++ {
+			v = append(v, m.Get(r, c))
+		}
+	}
+
+	return v, nil
+}
+
+//Vandermonde returns the square Vandermonde matrix of the values: row i holds the powers 1, v_i, v_i², ... of v_i.
+func Vandermonde(v []Frac) M {
+	return powerColumns(v, len(v))
+}
+
+//powerColumns returns the matrix whose row i holds the first k powers 1, v_i, v_i², ... of v_i.
+func powerColumns(v []Frac, k int) M {
+	rm := New(len(v), k)
+
+	for r, x := range v {
+		p := NewScalarFrac(1)
+		for c := 1; c <= k; c++ {
+			rm.Set(r+1, c, p)
+			p = p.Mul(x).Reduce()
+		}
+	}
+
+	return rm
+}
+
+//Hilbert returns the Hilbert matrix of size n, whose entry at row i and column j is 1/(i+j-1).
+func Hilbert(n int) M {
+	rm := New(n, n)
+
+	for r := 1; r <= n; r++ {
+		for c := 1; c <= n; c++ {
+			rm.Set(r, c, NewFrac(1, r+c-1))
+		}
+	}
+
+	return rm
+}
+
+//Toeplitz returns the matrix which is constant along each diagonal, with first column c and first row r.
+//It returns an error if c and r disagree about the top-left entry.
+func Toeplitz(c, r []Frac) (M, error) {
+	if len(c) == 0 || len(r) == 0 {
+		return New(0, 0), errors.New("the first column and row of a Toeplitz matrix must not be empty")
+	}
+
+	if !c[0].Equals(r[0]) {
+		return New(0, 0), fmt.Errorf("the first column starts with %v, but the first row starts with %v", c[0], r[0])
+	}
+
+	rm := New(len(c), len(r))
+
+	for i := 1; i <= len(c); i++ {
+		for j := 1; j <= len(r); j++ {
+			if i >= j {
+				rm.Set(i, j, c[i-j])
+			} else {
+				rm.Set(i, j, r[j-i])
+			}
+		}
+	}
+
+	return rm, nil
+}
+
+//Circulant returns the square matrix whose first row is v and whose every other row is the row above it shifted one place to the right.
+func Circulant(v []Frac) M {
+	n := len(v)
+	rm := New(n, n)
+
+	for r := 1; r <= n; r++ {
+		for c := 1; c <= n; c++ {
+			rm.Set(r, c, v[((c-r)%n+n)%n])
+		}
+	}
+
+	return rm
+}
+
+//Diagonal returns the square matrix with the values along its diagonal and zeros elsewhere.
+func Diagonal(v []Frac) M {
+	rm := New(len(v), len(v))
+
+	for i, x := range v {
+		rm.Set(i+1, i+1, x)
+	}
+
+	return rm
+}
+
+//Ones returns the matrix of size r,c with every entry equal to one.
+func Ones(r, c int) M {
+	rm := New(r, c)
+
+	for i := 1; i <= r; i++ {
+		for j := 1; j <= c; j++ {
+			rm.Set(i, j, NewScalarFrac(1))
+		}
+	}
+
+	return rm
+}
+
+//Permutation returns the permutation matrix whose row i is row p[i] of the identity, so that multiplying it on the left
+//of a matrix A lists the rows of A in the order p. An error is returned if p is not a permutation of 1 through len(p).
+func Permutation(p []int) (M, error) {
+	seen := make([]bool, len(p)+1)
+
+	for _, i := range p {
+		if i < 1 || i > len(p) {
+			return New(0, 0), fmt.Errorf("%d is not a row number between 1 and %d", i, len(p))
+		}
+
+		if seen[i] {
+			return New(0, 0), fmt.Errorf("row %d appears more than once", i)
+		}
+
+		seen[i] = true
+	}
+
+	rm := New(len(p), len(p))
+
+	for r, c := range p {
+		rm.Set(r+1, c, NewScalarFrac(1))
+	}
+
+	return rm, nil
+}
@@ -0,0 +1,96 @@
+package matrix
+
+import "testing"
+
+func fracs(vals ...int) []Frac {
+	fs := make([]Frac, len(vals))
+	for i, v := range vals {
+		fs[i] = NewScalarFrac(v)
+	}
+
+	return fs
+}
+
+func TestVandermonde(t *testing.T) {
+	expected := manualMatrix([][]string{
+		{"1", "1", "1"},
+		{"1", "2", "4"},
+		{"1", "-3", "9"},
+	})
+
+	if res := Vandermonde(fracs(1, 2, -3)); !matrixEquals(res, expected) {
+		t.Errorf("Incorrect Vandermonde matrix! Wanted\n %v but got\n %v", expected, res)
+	}
+}
+
+func TestHilbert(t *testing.T) {
+	expected := manualMatrix([][]string{
+		{"1", "1/2", "1/3"},
+		{"1/2", "1/3", "1/4"},
+		{"1/3", "1/4", "1/5"},
+	})
+
+	if res := Hilbert(3); !matrixEquals(res, expected) {
+		t.Errorf("Incorrect Hilbert matrix! Wanted\n %v but got\n %v", expected, res)
+	}
+}
+
+func TestToeplitz(t *testing.T) {
+	expected := manualMatrix([][]string{
+		{"1", "4", "5", "6"},
+		{"2", "1", "4", "5"},
+		{"3", "2", "1", "4"},
+	})
+
+	res, err := Toeplitz(fracs(1, 2, 3), fracs(1, 4, 5, 6))
+	if err != nil {
+		t.Fatalf("Got error during Toeplitz construction: %v", err)
+	}
+
+	if !matrixEquals(res, expected) {
+		t.Errorf("Incorrect Toeplitz matrix! Wanted\n %v but got\n %v", expected, res)
+	}
+
+	if _, err := Toeplitz(fracs(1, 2), fracs(2, 1)); err == nil {
+		t.Error("Disagreeing top-left entries must be rejected!")
+	}
+}
+
+func TestCirculant(t *testing.T) {
+	expected := manualMatrix([][]string{
+		{"1", "2", "3"},
+		{"3", "1", "2"},
+		{"2", "3", "1"},
+	})
+
+	if res := Circulant(fracs(1, 2, 3)); !matrixEquals(res, expected) {
+		t.Errorf("Incorrect circulant matrix! Wanted\n %v but got\n %v", expected, res)
+	}
+}
+
+func TestPermutation(t *testing.T) {
+	a := manualMatrix([][]string{
+		{"1", "2"},
+		{"3", "4"},
+		{"5", "6"},
+	})
+
+	expected := manualMatrix([][]string{
+		{"5", "6"},
+		{"1", "2"},
+		{"3", "4"},
+	})
+
+	p, err := Permutation([]int{3, 1, 2})
+	if err != nil {
+		t.Fatalf("Got error during permutation construction: %v", err)
+	}
+
+	if res, _ := Multiply(p, a); !matrixEquals(res, expected) {
+		t.Errorf("Permutation matrix reordered the rows incorrectly! Wanted\n %v but got\n %v", expected, res)
+	}
+
+	if _, err := Permutation([]int{1, 1, 2}); err == nil {
+		t.Error("Repeated rows must be rejected!")
+	}
+}
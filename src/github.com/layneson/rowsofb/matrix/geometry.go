@@ -0,0 +1,423 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//Line is the line through Point in the direction Dir, in R² or R³: the points Point + t·Dir.
+type Line struct {
+	Point, Dir []Frac
+}
+
+//Plane is the plane in R³ of the points x with Normal·x = Offset.
+type Plane struct {
+	Normal []Frac
+	Offset Frac
+}
+
+//NewLine returns the line through point in the direction dir.
+//An error is returned if the vectors are not both in R² or both in R³, or if dir is zero.
+func NewLine(point, dir []Frac) (Line, error) {
+	if err := checkGeometryVectors(point, dir); err != nil {
+		return Line{}, err
+	}
+
+	if isZeroVector(dir) {
+		return Line{}, errors.New("the direction of a line must not be zero")
+	}
+
+	return Line{Point: point, Dir: dir}, nil
+}
+
+//LineThrough returns the line through the points p and q, with direction q - p.
+//An error is returned if the points are the same, or if they are not both in R² or both in R³.
+func LineThrough(p, q []Frac) (Line, error) {
+	if err := checkGeometryVectors(p, q); err != nil {
+		return Line{}, err
+	}
+
+	if isZeroVector(subVectors(q, p)) {
+		return Line{}, errors.New("a line needs two different points")
+	}
+
+	return Line{Point: p, Dir: subVectors(q, p)}, nil
+}
+
+//NewPlane returns the plane through point with the given normal vector.
+//An error is returned if the vectors are not both in R³, or if the normal is zero.
+func NewPlane(point, normal []Frac) (Plane, error) {
+	if len(point) != 3 || len(normal) != 3 {
+		return Plane{}, errors.New("planes are only defined here in R³")
+	}
+
+	if isZeroVector(normal) {
+		return Plane{}, errors.New("the normal of a plane must not be zero")
+	}
+
+	return Plane{Normal: normal, Offset: dot(normal, point)}, nil
+}
+
+//PlaneThrough returns the plane through the points p, q and r in R³, whose normal is (q - p) × (r - p).
+//An error is returned if the points lie on one line.
+func PlaneThrough(p, q, r []Frac) (Plane, error) {
+	if len(p) != 3 || len(q) != 3 || len(r) != 3 {
+		return Plane{}, errors.New("planes are only defined here in R³")
+	}
+
+	n := cross(subVectors(q, p), subVectors(r, p))
+	if isZeroVector(n) {
+		return Plane{}, errors.New("the three points lie on one line, so they do not determine a plane")
+	}
+
+	return Plane{Normal: n, Offset: dot(n, p)}, nil
+}
+
+//Param returns a point of the plane and two directions spanning it, so that the plane is the points p + s·u + t·v.
+func (pl Plane) Param() (p, u, v []Frac) {
+	sol, _, _ := solveLinear(vectorRows(pl.Normal), []Frac{pl.Offset})
+
+	dirs := NullSpace(vectorRows(pl.Normal))
+
+	return sol, columnEntries(dirs, 1), columnEntries(dirs, 2)
+}
+
+//String returns the line in parametric form, such as "x = (1, 2, 0) + t(1, -1, 3)".
+func (l Line) String() string {
+	return fmt.Sprintf("x = %s + t%s", VectorString(l.Point), VectorString(l.Dir))
+}
+
+//String returns the plane in parametric form followed by its equation, such as "x = (0, 0, 1) + s(1, 0, 0) + t(0, 1, 0), or z = 1".
+func (pl Plane) String() string {
+	p, u, v := pl.Param()
+
+	terms := []string{}
+	for i, name := range []string{"x", "y", "z"} {
+		c := pl.Normal[i].Reduce()
+
+		switch {
+		case c.IsZero():
+			continue
+		case c.Equals(NewScalarFrac(1)):
+			terms = append(terms, name)
+		case c.Equals(NewScalarFrac(-1)):
+			terms = append(terms, "-"+name)
+		case c.IsWhole():
+			terms = append(terms, c.String()+name)
+		default:
+			terms = append(terms, "("+c.String()+")"+name)
+		}
+	}
+
+	eq := terms[0]
+	for _, t := range terms[1:] {
+		if strings.HasPrefix(t, "-") {
+			eq += " - " + t[1:]
+		} else {
+			eq += " + " + t
+		}
+	}
+
+	return fmt.Sprintf("x = %s + s%s + t%s, or %s = %v", VectorString(p), VectorString(u), VectorString(v), eq, pl.Offset.Reduce())
+}
+
+//VectorString writes a vector as a list of entries in parentheses, such as "(1, -2, 1/2)".
+func VectorString(v []Frac) string {
+	strs := make([]string, len(v))
+	for i, f := range v {
+		strs[i] = f.Reduce().String()
+	}
+
+	return "(" + strings.Join(strs, ", ") + ")"
+}
+
+//Relation describes how two lines or planes meet.
+type Relation int
+
+//Relation definitions
+const (
+	Intersecting Relation = iota // they meet in a single point, or two planes meet in a line
+	Parallel                     // they have the same direction but never meet
+	Skew                         // two lines in R³ which are not parallel and never meet
+	Coincident                   // one lies inside the other
+)
+
+func (r Relation) String() string {
+	switch r {
+	case Intersecting:
+		return "intersecting"
+	case Parallel:
+		return "parallel"
+	case Skew:
+		return "skew"
+	case Coincident:
+		return "coincident"
+	}
+
+	return "unknown"
+}
+
+//Intersection is the result of intersecting two lines or planes. If they meet in a point, Point holds it.
+//If they meet in a line, because two planes cross or because one object lies inside the other, Line holds it.
+type Intersection struct {
+	Relation Relation
+	Point    []Frac
+	Line     *Line
+}
+
+//IntersectLines intersects two lines by solving p1 + s·d1 = p2 + t·d2 with Rref.
+//An error is returned if one line is in R² and the other in R³.
+func IntersectLines(l1, l2 Line) (Intersection, error) {
+	if len(l1.Point) != len(l2.Point) {
+		return Intersection{}, errors.New("cannot intersect a line in R² with a line in R³")
+	}
+
+	a := New(len(l1.Point), 2)
+	for i := range l1.Point {
+		a.Set(i+1, 1, l1.Dir[i])
+		a.Set(i+1, 2, l2.Dir[i].Neg())
+	}
+
+	sol, rank, ok := solveLinear(a, subVectors(l2.Point, l1.Point))
+
+	switch {
+	case !ok && rank == 1:
+		return Intersection{Relation: Parallel}, nil
+	case !ok:
+		return Intersection{Relation: Skew}, nil
+	case rank == 1:
+		return Intersection{Relation: Coincident, Line: &l1}, nil
+	}
+
+	return Intersection{Relation: Intersecting, Point: addVectors(l1.Point, scaleVector(sol[0], l1.Dir))}, nil
+}
+
+//IntersectLinePlane intersects a line and a plane in R³ by solving n·(p + t·d) = c with Rref.
+//An error is returned if the line is not in R³.
+func IntersectLinePlane(l Line, pl Plane) (Intersection, error) {
+	if len(l.Point) != 3 {
+		return Intersection{}, errors.New("a line must be in R³ to meet a plane")
+	}
+
+	a := New(1, 1)
+	a.Set(1, 1, dot(pl.Normal, l.Dir))
+
+	sol, _, ok := solveLinear(a, []Frac{pl.Offset.Add(dot(pl.Normal, l.Point).Neg())})
+
+	switch {
+	case !ok:
+		return Intersection{Relation: Parallel}, nil
+	case a.Get(1, 1).IsZero():
+		return Intersection{Relation: Coincident, Line: &l}, nil
+	}
+
+	return Intersection{Relation: Intersecting, Point: addVectors(l.Point, scaleVector(sol[0], l.Dir))}, nil
+}
+
+//IntersectPlanes intersects two planes by solving their equations together with Rref.
+//If they cross, the line of intersection has direction n1 × n2, found as the null space of the system.
+func IntersectPlanes(p1, p2 Plane) Intersection {
+	a := New(2, 3)
+	for i := 0; i < 3; i++ {
+		a.Set(1, i+1, p1.Normal[i])
+		a.Set(2, i+1, p2.Normal[i])
+	}
+
+	sol, rank, ok := solveLinear(a, []Frac{p1.Offset, p2.Offset})
+
+	switch {
+	case !ok:
+		return Intersection{Relation: Parallel}
+	case rank == 1:
+		return Intersection{Relation: Coincident}
+	}
+
+	return Intersection{Relation: Intersecting, Line: &Line{Point: sol, Dir: columnEntries(NullSpace(a), 1)}}
+}
+
+//ProjectOntoLine returns the point of the line closest to p: Point + ((p - Point)·Dir / Dir·Dir)·Dir.
+//An error is returned if p is not in the same space as the line.
+func ProjectOntoLine(p []Frac, l Line) ([]Frac, error) {
+	if len(p) != len(l.Point) {
+		return nil, fmt.Errorf("the point has %d coordinates, but the points of the line have %d", len(p), len(l.Point))
+	}
+
+	t := dot(subVectors(p, l.Point), l.Dir).Div(dot(l.Dir, l.Dir))
+
+	return addVectors(l.Point, scaleVector(t, l.Dir)), nil
+}
+
+//ProjectOntoPlane returns the point of the plane closest to p: p - ((n·p - c) / n·n)·n.
+//An error is returned if p is not in R³.
+func ProjectOntoPlane(p []Frac, pl Plane) ([]Frac, error) {
+	if len(p) != 3 {
+		return nil, errors.New("the point must be in R³ to be projected onto a plane")
+	}
+
+	t := dot(pl.Normal, p).Add(pl.Offset.Neg()).Div(dot(pl.Normal, pl.Normal))
+
+	return subVectors(p, scaleVector(t, pl.Normal)), nil
+}
+
+//DistanceToLine returns the exact distance from p to the line, the length of p minus its projection.
+func DistanceToLine(p []Frac, l Line) (Radical, error) {
+	q, err := ProjectOntoLine(p, l)
+	if err != nil {
+		return Radical{}, err
+	}
+
+	return length(subVectors(p, q)), nil
+}
+
+//DistanceToPlane returns the exact distance from p to the plane, |n·p - c| / |n|.
+func DistanceToPlane(p []Frac, pl Plane) (Radical, error) {
+	q, err := ProjectOntoPlane(p, pl)
+	if err != nil {
+		return Radical{}, err
+	}
+
+	return length(subVectors(p, q)), nil
+}
+
+//DistanceBetweenLines returns the exact distance between two lines. Parallel lines are as far apart as a point of one
+//is from the other; skew lines are |(p2 - p1)·(d1 × d2)| / |d1 × d2| apart; lines which meet are zero apart.
+func DistanceBetweenLines(l1, l2 Line) (Radical, error) {
+	in, err := IntersectLines(l1, l2)
+	if err != nil {
+		return Radical{}, err
+	}
+
+	switch in.Relation {
+	case Parallel:
+		return DistanceToLine(l2.Point, l1)
+	case Skew:
+		n := cross(l1.Dir, l2.Dir)
+		d := dot(subVectors(l2.Point, l1.Point), n)
+
+		r, _ := SqrtFrac(d.Mul(d).Div(dot(n, n)))
+
+		return r, nil
+	}
+
+	return NewRadical(NewScalarFrac(0), 1), nil
+}
+
+//solveLinear solves ax = b by reducing the augmented matrix with Rref. It returns the solution with every free variable
+//set to zero, the rank of a, and false if the system is inconsistent.
+func solveLinear(a M, b []Frac) ([]Frac, int, bool) {
+	aug, _ := Augment(a, columnVector(b))
+	r := Rref(aug)
+
+	sol := make([]Frac, a.Cols())
+	for i := range sol {
+		sol[i] = NewScalarFrac(0)
+	}
+
+	rank := 0
+	for i := 1; i <= r.Rows(); i++ {
+		lead := 0
+		for c := 1; c <= r.Cols(); c++ {
+			if !r.Get(i, c).IsZero() {
+				lead = c
+				break
+			}
+		}
+
+		switch {
+		case lead == 0:
+			continue
+		case lead == r.Cols():
+			return nil, rank, false
+		}
+
+		rank++
+		sol[lead-1] = r.Get(i, r.Cols()).Reduce()
+	}
+
+	return sol, rank, true
+}
+
+//checkGeometryVectors checks that two vectors are both in R² or both in R³.
+func checkGeometryVectors(u, v []Frac) error {
+	if len(u) != len(v) {
+		return fmt.Errorf("cannot combine a vector with %d coordinates and a vector with %d", len(u), len(v))
+	}
+
+	if len(u) != 2 && len(u) != 3 {
+		return fmt.Errorf("lines are only defined here in R² and R³, not with %d coordinates", len(u))
+	}
+
+	return nil
+}
+
+//vectorRows returns the vector as a matrix with one row.
+func vectorRows(v []Frac) M {
+	return Transpose(columnVector(v))
+}
+
+//columnEntries returns column c of the matrix as a vector.
+func columnEntries(m M, c int) []Frac {
+	v := make([]Frac, m.Rows())
+	for r := range v {
+		v[r] = m.Get(r+1, c).Reduce()
+	}
+
+	return v
+}
+
+func dot(u, v []Frac) Frac {
+	s := NewScalarFrac(0)
+	for i := range u {
+		s = s.Add(u[i].Mul(v[i])).Reduce()
+	}
+
+	return s
+}
+
+func cross(u, v []Frac) []Frac {
+	return []Frac{
+		u[1].Mul(v[2]).Add(u[2].Mul(v[1]).Neg()).Reduce(),
+		u[2].Mul(v[0]).Add(u[0].Mul(v[2]).Neg()).Reduce(),
+		u[0].Mul(v[1]).Add(u[1].Mul(v[0]).Neg()).Reduce(),
+	}
+}
+
+func addVectors(u, v []Frac) []Frac {
+	w := make([]Frac, len(u))
+	for i := range u {
+		w[i] = u[i].Add(v[i]).Reduce()
+	}
+
+	return w
+}
+
+func subVectors(u, v []Frac) []Frac {
+	return addVectors(u, scaleVector(NewScalarFrac(-1), v))
+}
+
+func scaleVector(s Frac, v []Frac) []Frac {
+	w := make([]Frac, len(v))
+	for i := range v {
+		w[i] = s.Mul(v[i]).Reduce()
+	}
+
+	return w
+}
+
+func isZeroVector(v []Frac) bool {
+	for _, f := range v {
+		if !f.IsZero() {
+			return false
+		}
+	}
+
+	return true
+}
+
+//length returns the exact length of a vector.
+func length(v []Frac) Radical {
+	r, _ := SqrtFrac(dot(v, v))
+
+	return r
+}
@@ -0,0 +1,112 @@
+package matrix
+
+import "testing"
+
+func TestIntersectLines(t *testing.T) {
+	l1, _ := LineThrough(fracs(0, 0), fracs(2, 2))
+	l2, _ := LineThrough(fracs(0, 2), fracs(2, 0))
+
+	in, err := IntersectLines(l1, l2)
+	if err != nil {
+		t.Fatalf("Got error while intersecting lines: %v", err)
+	}
+
+	if in.Relation != Intersecting || VectorString(in.Point) != "(1, 1)" {
+		t.Errorf("The diagonals of the square should meet at (1, 1), but were %v at %v", in.Relation, in.Point)
+	}
+
+	tests := []struct {
+		p1, d1, p2, d2 []Frac
+		expected       Relation
+	}{
+		{fracs(0, 0, 0), fracs(1, 0, 0), fracs(0, 1, 0), fracs(2, 0, 0), Parallel},
+		{fracs(0, 0, 0), fracs(1, 0, 0), fracs(0, 1, 1), fracs(0, 1, 0), Skew},
+		{fracs(0, 0, 0), fracs(1, 1, 1), fracs(2, 2, 2), fracs(-3, -3, -3), Coincident},
+	}
+
+	for _, test := range tests {
+		l1, _ := NewLine(test.p1, test.d1)
+		l2, _ := NewLine(test.p2, test.d2)
+
+		in, _ := IntersectLines(l1, l2)
+		if in.Relation != test.expected {
+			t.Errorf("%v and %v should be %v but were %v", l1, l2, test.expected, in.Relation)
+		}
+	}
+
+	if _, err := LineThrough(fracs(1, 2), fracs(1, 2)); err == nil {
+		t.Error("A line through one point twice should be rejected")
+	}
+}
+
+func TestIntersectPlanes(t *testing.T) {
+	pl, err := PlaneThrough(fracs(1, 0, 0), fracs(0, 1, 0), fracs(0, 0, 1))
+	if err != nil {
+		t.Fatalf("Got error while building a plane: %v", err)
+	}
+
+	l, _ := NewLine(fracs(0, 0, 0), fracs(1, 1, 1))
+	in, _ := IntersectLinePlane(l, pl)
+	if in.Relation != Intersecting || VectorString(in.Point) != "(1/3, 1/3, 1/3)" {
+		t.Errorf("The line x = y = z should meet x + y + z = 1 at (1/3, 1/3, 1/3), but was %v at %v", in.Relation, in.Point)
+	}
+
+	inside, _ := LineThrough(fracs(1, 0, 0), fracs(0, 1, 0))
+	if in, _ := IntersectLinePlane(inside, pl); in.Relation != Coincident {
+		t.Errorf("A line through two points of the plane should lie in it, but was %v", in.Relation)
+	}
+
+	xy, _ := NewPlane(fracs(0, 0, 0), fracs(0, 0, 1))
+	in = IntersectPlanes(pl, xy)
+	if in.Relation != Intersecting {
+		t.Fatalf("x + y + z = 1 should cross the xy-plane, but was %v", in.Relation)
+	}
+
+	for _, s := range []int{0, 1, 5} {
+		p := addVectors(in.Line.Point, scaleVector(NewScalarFrac(s), in.Line.Dir))
+		if !dot(pl.Normal, p).Equals(pl.Offset) || !p[2].IsZero() {
+			t.Errorf("%v is on the line of intersection %v, but not on both planes", p, in.Line)
+		}
+	}
+
+	shifted, _ := NewPlane(fracs(0, 0, 3), fracs(0, 0, 2))
+	if in := IntersectPlanes(xy, shifted); in.Relation != Parallel {
+		t.Errorf("z = 0 and z = 3 should be parallel, but were %v", in.Relation)
+	}
+
+	same, _ := NewPlane(fracs(5, 5, 0), fracs(0, 0, -1))
+	if in := IntersectPlanes(xy, same); in.Relation != Coincident {
+		t.Errorf("z = 0 and -z = 0 should be coincident, but were %v", in.Relation)
+	}
+
+	if _, err := PlaneThrough(fracs(0, 0, 0), fracs(1, 1, 1), fracs(2, 2, 2)); err == nil {
+		t.Error("Three points on one line should not determine a plane")
+	}
+}
+
+func TestDistances(t *testing.T) {
+	pl, _ := NewPlane(fracs(0, 0, 0), fracs(1, 2, 2))
+
+	d, _ := DistanceToPlane(fracs(3, 0, 0), pl)
+	if d.String() != "1" {
+		t.Errorf("The distance from (3, 0, 0) to x + 2y + 2z = 0 should be 1, but was %v", d)
+	}
+
+	proj, _ := ProjectOntoPlane(fracs(3, 0, 0), pl)
+	if VectorString(proj) != "(8/3, -2/3, -2/3)" {
+		t.Errorf("The projection of (3, 0, 0) onto x + 2y + 2z = 0 should be (8/3, -2/3, -2/3), but was %s", VectorString(proj))
+	}
+
+	l, _ := NewLine(fracs(0, 0), fracs(1, 1))
+	d, _ = DistanceToLine(fracs(2, 0), l)
+	if d.String() != "√2" {
+		t.Errorf("The distance from (2, 0) to y = x should be √2, but was %v", d)
+	}
+
+	l1, _ := NewLine(fracs(0, 0, 0), fracs(1, 0, 0))
+	l2, _ := NewLine(fracs(0, 0, 2), fracs(0, 1, 0))
+	d, _ = DistanceBetweenLines(l1, l2)
+	if d.String() != "2" {
+		t.Errorf("The skew lines should be 2 apart, but were %v apart", d)
+	}
+}
@@ -0,0 +1,179 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//Edge is an edge of a graph between two vertices, numbered from 1. In a directed graph it runs from From to To.
+type Edge struct {
+	From, To int
+}
+
+//ParseEdgeList parses a graph written as a comma-separated list of edges, such as "1-2, 2-3, 3-1" for an undirected graph
+//or "1->2, 2->3" for a directed one. It returns the adjacency matrix, whose size is the largest vertex number.
+//An edge listed twice adds two to the adjacency count; loops are not allowed.
+func ParseEdgeList(s string) (M, error) {
+	edges := []Edge{}
+	directed := false
+	n := 0
+
+	for i, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+
+		sep := "-"
+		if strings.Contains(part, "->") {
+			sep = "->"
+		}
+
+		if i > 0 && directed != (sep == "->") {
+			return M{}, errors.New("a graph cannot mix directed and undirected edges")
+		}
+		directed = sep == "->"
+
+		ends := strings.Split(part, sep)
+		if len(ends) != 2 {
+			return M{}, fmt.Errorf("edge %d, %q, must join two vertices, as in 1-2 or 1->2", i+1, part)
+		}
+
+		e := Edge{}
+		for j, end := range ends {
+			v, err := strconv.Atoi(strings.TrimSpace(end))
+			if err != nil || v < 1 {
+				return M{}, fmt.Errorf("edge %d has the invalid vertex %q; vertices are numbered from 1", i+1, strings.TrimSpace(end))
+			}
+
+			if j == 0 {
+				e.From = v
+			} else {
+				e.To = v
+			}
+
+			if v > n {
+				n = v
+			}
+		}
+
+		if e.From == e.To {
+			return M{}, fmt.Errorf("edge %d is a loop at vertex %d", i+1, e.From)
+		}
+
+		edges = append(edges, e)
+	}
+
+	adj := New(n, n)
+	for _, e := range edges {
+		adj.Set(e.From, e.To, adj.Get(e.From, e.To).Add(NewScalarFrac(1)))
+		if !directed {
+			adj.Set(e.To, e.From, adj.Get(e.To, e.From).Add(NewScalarFrac(1)))
+		}
+	}
+
+	return adj, nil
+}
+
+//IsDirected returns true if the adjacency matrix is not symmetric, so that it must describe a directed graph.
+func IsDirected(adj M) bool {
+	return !adj.Equals(Transpose(adj))
+}
+
+//Edges lists the edges of the graph with the given adjacency matrix, ordered by their endpoints.
+//An error is returned if the matrix is not square, has an entry which is not a non-negative integer, or has a loop.
+func Edges(adj M) ([]Edge, error) {
+	if adj.Rows() != adj.Cols() {
+		return nil, errors.New("an adjacency matrix must be square")
+	}
+
+	directed := IsDirected(adj)
+	edges := []Edge{}
+
+	for r := 1; r <= adj.Rows(); r++ {
+		for c := 1; c <= adj.Cols(); c++ {
+			v := adj.Get(r, c)
+			if !v.IsWhole() || v.Numerator() < 0 {
+				return nil, fmt.Errorf("entry %d,%d is %v, which is not a number of edges", r, c, v)
+			}
+
+			if r == c && !v.IsZero() {
+				return nil, fmt.Errorf("vertex %d has a loop", r)
+			}
+
+			if !directed && c < r {
+				continue
+			}
+
+			for k := 0; k < v.Reduce().Integer(); k++ {
+				edges = append(edges, Edge{From: r, To: c})
+			}
+		}
+	}
+
+	return edges, nil
+}
+
+//Incidence returns the incidence matrix of the graph with the given adjacency matrix, with a row for each vertex
+//and a column for each edge in the order of Edges. In an undirected graph both ends of an edge hold 1;
+//in a directed graph the edge leaves a vertex holding -1 and enters one holding 1.
+func Incidence(adj M) (M, []Edge, error) {
+	edges, err := Edges(adj)
+	if err != nil {
+		return M{}, nil, err
+	}
+
+	from := NewScalarFrac(1)
+	if IsDirected(adj) {
+		from = NewScalarFrac(-1)
+	}
+
+	rm := New(adj.Rows(), len(edges))
+	for i, e := range edges {
+		rm.Set(e.From, i+1, from)
+		rm.Set(e.To, i+1, NewScalarFrac(1))
+	}
+
+	return rm, edges, nil
+}
+
+//Laplacian returns the Laplacian matrix D - A of the undirected graph with adjacency matrix adj,
+//where D holds the vertex degrees along its diagonal.
+//An error is returned if the graph is directed or adj is not an adjacency matrix.
+func Laplacian(adj M) (M, error) {
+	if _, err := Edges(adj); err != nil {
+		return M{}, err
+	}
+
+	if IsDirected(adj) {
+		return M{}, errors.New("the Laplacian is only defined here for undirected graphs")
+	}
+
+	rm := Scale(NewScalarFrac(-1), adj)
+	for r := 1; r <= adj.Rows(); r++ {
+		deg := NewScalarFrac(0)
+		for c := 1; c <= adj.Cols(); c++ {
+			deg = deg.Add(adj.Get(r, c))
+		}
+
+		rm.Set(r, r, deg.Reduce())
+	}
+
+	return rm, nil
+}
+
+//SpanningTrees counts the spanning trees of the undirected graph with adjacency matrix adj using the matrix-tree theorem:
+//the count is any cofactor of the Laplacian, here the determinant left after deleting its first row and column.
+func SpanningTrees(adj M) (Frac, error) {
+	l, err := Laplacian(adj)
+	if err != nil {
+		return Frac{}, err
+	}
+
+	if l.Rows() <= 1 {
+		return NewScalarFrac(1), nil
+	}
+
+	minor, _ := Submatrix(l, 2, l.Rows(), 2, l.Cols())
+
+	return Determinant(minor)
+}
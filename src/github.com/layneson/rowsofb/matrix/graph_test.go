@@ -0,0 +1,78 @@
+package matrix
+
+import "testing"
+
+func TestParseEdgeList(t *testing.T) {
+	adj, err := ParseEdgeList("1-2, 2-3,3-1, 1-2")
+	if err != nil {
+		t.Fatalf("Got error during edge list parsing: %v", err)
+	}
+
+	expected := manualMatrix([][]string{
+		{"0", "2", "1"},
+		{"2", "0", "1"},
+		{"1", "1", "0"},
+	})
+
+	if !matrixEquals(adj, expected) {
+		t.Errorf("Incorrect adjacency matrix! Wanted\n %v but got\n %v", expected, adj)
+	}
+
+	directed, err := ParseEdgeList("1->2, 3->1")
+	if err != nil {
+		t.Fatalf("Got error during edge list parsing: %v", err)
+	}
+
+	if !IsDirected(directed) || !directed.Get(3, 1).Equals(NewScalarFrac(1)) || !directed.Get(1, 3).IsZero() {
+		t.Errorf("Incorrect directed adjacency matrix:\n %v", directed)
+	}
+
+	for _, bad := range []string{"1-2, 2->3", "1-1", "1-", "0-2", "1-2-3"} {
+		if _, err := ParseEdgeList(bad); err == nil {
+			t.Errorf("Edge list %q must be rejected!", bad)
+		}
+	}
+}
+
+func TestIncidence(t *testing.T) {
+	adj, _ := ParseEdgeList("1->2, 2->3")
+
+	inc, edges, err := Incidence(adj)
+	if err != nil {
+		t.Fatalf("Got error during incidence calculation: %v", err)
+	}
+
+	expected := manualMatrix([][]string{
+		{"-1", "0"},
+		{"1", "-1"},
+		{"0", "1"},
+	})
+
+	if !matrixEquals(inc, expected) || len(edges) != 2 {
+		t.Errorf("Incorrect incidence matrix! Wanted\n %v but got\n %v", expected, inc)
+	}
+}
+
+func TestSpanningTrees(t *testing.T) {
+	//The complete graph on n vertices has n^(n-2) spanning trees.
+	k4, _ := ParseEdgeList("1-2, 1-3, 1-4, 2-3, 2-4, 3-4")
+
+	count, err := SpanningTrees(k4)
+	if err != nil {
+		t.Fatalf("Got error during spanning tree count: %v", err)
+	}
+
+	if !count.Equals(NewScalarFrac(16)) {
+		t.Errorf("K4 has 16 spanning trees, but got %v", count)
+	}
+
+	disconnected, _ := ParseEdgeList("1-2, 3-4")
+	if count, _ := SpanningTrees(disconnected); !count.IsZero() {
+		t.Errorf("A disconnected graph has no spanning trees, but got %v", count)
+	}
+
+	directed, _ := ParseEdgeList("1->2")
+	if _, err := SpanningTrees(directed); err == nil {
+		t.Error("Spanning trees of a directed graph must be rejected!")
+	}
+}
@@ -0,0 +1,88 @@
+package matrix
+
+import (
+	"fmt"
+	"strings"
+)
+
+//HillEncrypt encrypts text with the Hill cipher using the square key matrix. Letters are numbered A=0 through Z=25
+//and everything else is dropped; the letters are split into column vectors the size of the key, padded with X,
+//and each vector v is replaced by key·v modulo 26.
+//An error is returned if the key has no inverse modulo 26, since the ciphertext could not then be decrypted.
+func HillEncrypt(key M, text string) (string, error) {
+	if _, _, _, err := unitDeterminant(key, 26); err != nil {
+		return "", err
+	}
+
+	letters := hillLetters(text)
+	if len(letters) == 0 {
+		return "", fmt.Errorf("%q contains no letters", text)
+	}
+
+	for len(letters)%key.Rows() != 0 {
+		letters = append(letters, 'X'-'A')
+	}
+
+	return hillApply(key, letters)
+}
+
+//HillDecrypt decrypts text encrypted with the Hill cipher using the given key matrix, by applying the inverse of the key modulo 26.
+//The inverse key is returned along with the plaintext.
+//An error is returned if the key has no inverse modulo 26, or if the number of letters is not a multiple of the size of the key.
+func HillDecrypt(key M, text string) (string, M, error) {
+	inv, err := InverseModN(key, 26)
+	if err != nil {
+		return "", inv, err
+	}
+
+	letters := hillLetters(text)
+	if len(letters) == 0 {
+		return "", inv, fmt.Errorf("%q contains no letters", text)
+	}
+
+	if len(letters)%key.Rows() != 0 {
+		return "", inv, fmt.Errorf("the ciphertext has %d letters, which is not a multiple of the key size %d", len(letters), key.Rows())
+	}
+
+	p, err := hillApply(inv, letters)
+
+	return p, inv, err
+}
+
+//hillLetters returns the numbers of the letters in text, ignoring case and skipping anything which is not a letter from A to Z.
+func hillLetters(text string) []int {
+	letters := []int{}
+
+	for _, r := range strings.ToUpper(text) {
+		if r >= 'A' && r <= 'Z' {
+			letters = append(letters, int(r-'A'))
+		}
+	}
+
+	return letters
+}
+
+//hillApply multiplies each block of letters by the key modulo 26 and returns the resulting letters.
+func hillApply(key M, letters []int) (string, error) {
+	n := key.Rows()
+	var sb strings.Builder
+
+	for i := 0; i < len(letters); i += n {
+		v := New(n, 1)
+		for j := 0; j < n; j++ {
+			v.Set(j+1, 1, NewScalarFrac(letters[i+j]))
+		}
+
+		w, _ := Multiply(key, v)
+		w, err := ReduceMod(w, 26)
+		if err != nil {
+			return "", err
+		}
+
+		for j := 1; j <= n; j++ {
+			sb.WriteRune(rune('A' + w.Get(j, 1).Reduce().Integer()))
+		}
+	}
+
+	return sb.String(), nil
+}
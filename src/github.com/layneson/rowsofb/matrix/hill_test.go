@@ -0,0 +1,59 @@
+package matrix
+
+import "testing"
+
+func TestHill(t *testing.T) {
+	key := manualMatrix([][]string{
+		{"3", "3"},
+		{"2", "5"},
+	})
+
+	c, err := HillEncrypt(key, "help")
+	if err != nil {
+		t.Fatalf("Got error during encryption: %v", err)
+	}
+
+	if c != "HIAT" {
+		t.Errorf("Encrypting HELP should give HIAT but gave %s", c)
+	}
+
+	p, inv, err := HillDecrypt(key, c)
+	if err != nil {
+		t.Fatalf("Got error during decryption: %v", err)
+	}
+
+	if p != "HELP" {
+		t.Errorf("Decrypting HIAT should give HELP but gave %s", p)
+	}
+
+	id, _ := Multiply(key, inv)
+	if id, _ = ReduceMod(id, 26); !matrixEquals(id, Identity(2)) {
+		t.Errorf("The returned inverse key\n%v\nis not the inverse of the key modulo 26", inv)
+	}
+
+	c, err = HillEncrypt(key, "Hi, you!")
+	if err != nil {
+		t.Fatalf("Got error during encryption: %v", err)
+	}
+
+	if len(c) != 6 {
+		t.Errorf("HIYOU should be padded to 6 letters, but encrypted to %s", c)
+	}
+
+	if p, _, _ := HillDecrypt(key, c); p != "HIYOUX" {
+		t.Errorf("Decrypting %s should give HIYOUX but gave %s", c, p)
+	}
+
+	if _, _, err := HillDecrypt(key, "ABC"); err == nil {
+		t.Error("Decrypting 3 letters with a 2x2 key should fail")
+	}
+
+	bad := manualMatrix([][]string{
+		{"2", "4"},
+		{"1", "3"},
+	})
+
+	if _, err := HillEncrypt(bad, "help"); err == nil {
+		t.Error("A key with determinant 2 should be rejected")
+	}
+}
@@ -0,0 +1,170 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+)
+
+//Stochastic checks that p is the transition matrix of a Markov chain: square, with non-negative entries, and with every row
+//or every column summing to one. It returns true if the rows sum to one, so that entry i,j is the probability of moving
+//from state i to state j, and false if only the columns do, in which case entry i,j is the probability of moving from j to i.
+//The error names the first row which breaks the rules.
+func Stochastic(p M) (bool, error) {
+	if p.Rows() != p.Cols() {
+		return false, errors.New("a transition matrix must be square")
+	}
+
+	for r := 1; r <= p.Rows(); r++ {
+		for c := 1; c <= p.Cols(); c++ {
+			if p.Get(r, c).Numerator() < 0 {
+				return false, fmt.Errorf("entry %d,%d is the negative probability %v", r, c, p.Get(r, c))
+			}
+		}
+	}
+
+	badRow, badCol := 0, 0
+	var rowSum, colSum Frac
+
+	for i := 1; i <= p.Rows(); i++ {
+		rs, cs := NewScalarFrac(0), NewScalarFrac(0)
+		for j := 1; j <= p.Cols(); j++ {
+			rs = rs.Add(p.Get(i, j)).Reduce()
+			cs = cs.Add(p.Get(j, i)).Reduce()
+		}
+
+		if badRow == 0 && !rs.Equals(NewScalarFrac(1)) {
+			badRow, rowSum = i, rs
+		}
+
+		if badCol == 0 && !cs.Equals(NewScalarFrac(1)) {
+			badCol, colSum = i, cs
+		}
+	}
+
+	switch {
+	case badRow == 0:
+		return true, nil
+	case badCol == 0:
+		return false, nil
+	}
+
+	return false, fmt.Errorf("neither the rows nor the columns sum to one: row %d sums to %v and column %d sums to %v", badRow, rowSum, badCol, colSum)
+}
+
+//SteadyState returns the stationary distribution of a Markov chain whose transition matrix has rows summing to one:
+//the row vector π with πp = π whose entries sum to one. An error is returned if the distribution is not unique.
+func SteadyState(p M) (M, error) {
+	//π(p - I) = 0 is the null space of the transpose of p - I.
+	shifted, _ := Add(Transpose(p), Scale(NewScalarFrac(-1), Identity(p.Rows())))
+	null := NullSpace(shifted)
+
+	if null.Cols() > 1 {
+		return New(0, 0), fmt.Errorf("the chain has %d independent stationary distributions", null.Cols())
+	}
+
+	sum := NewScalarFrac(0)
+	for r := 1; r <= null.Rows(); r++ {
+		sum = sum.Add(null.Get(r, 1)).Reduce()
+	}
+
+	return Scale(sum.Inv(), Transpose(null)), nil
+}
+
+//Distribution returns the row vector xpⁿ: the distribution after n steps of a Markov chain whose transition matrix p has rows
+//summing to one, starting from the distribution x. An error is returned if the entries grow too large to be represented exactly.
+func Distribution(p, x M, n int) (M, error) {
+	pn, err := PowerChecked(p, n)
+	if err != nil {
+		return x, fmt.Errorf("the distribution after %d steps cannot be computed: %v", n, err)
+	}
+
+	xn, ok := multiplyChecked(x, pn)
+
+	//A probability vector stays one, so anything else means the arithmetic went wrong.
+	if !ok || isProbabilityVector(x) && !isProbabilityVector(xn) {
+		return x, fmt.Errorf("the distribution after %d steps cannot be computed: %v", n, errOverflow)
+	}
+
+	return xn, nil
+}
+
+//isProbabilityVector returns true if the entries of the row vector x are non-negative and sum to one.
+func isProbabilityVector(x M) bool {
+	sum := NewScalarFrac(0)
+	for c := 1; c <= x.Cols(); c++ {
+		if x.Get(1, c).Numerator() < 0 {
+			return false
+		}
+
+		var ok bool
+		if sum, ok = sum.addChecked(x.Get(1, c)); !ok {
+			return false
+		}
+	}
+
+	return sum.Equals(NewScalarFrac(1))
+}
+
+//AbsorbingChain describes an absorbing Markov chain whose transition matrix has rows summing to one.
+//State numbers count from 1.
+type AbsorbingChain struct {
+	Transient, Absorbing []int
+
+	Fundamental   M //(I - Q)⁻¹: entry i,j is the expected number of visits to transient state j starting from transient state i
+	Steps         M //column vector of the expected number of steps before absorption from each transient state
+	Probabilities M //entry i,j is the probability that transient state i ends in absorbing state j
+}
+
+//Absorb analyzes an absorbing Markov chain whose transition matrix has rows summing to one.
+//A state is absorbing if it cannot be left. An error is returned if there is no absorbing state,
+//or if some transient state cannot reach one.
+func Absorb(p M) (AbsorbingChain, error) {
+	chain := AbsorbingChain{}
+
+	for i := 1; i <= p.Rows(); i++ {
+		if p.Get(i, i).Equals(NewScalarFrac(1)) {
+			chain.Absorbing = append(chain.Absorbing, i)
+		} else {
+			chain.Transient = append(chain.Transient, i)
+		}
+	}
+
+	if len(chain.Absorbing) == 0 {
+		return chain, errors.New("the chain has no absorbing states")
+	}
+
+	if len(chain.Transient) == 0 {
+		return chain, errors.New("the chain has no transient states")
+	}
+
+	//Split p into Q, the transitions among transient states, and r, the transitions into absorbing states. iq holds I - Q.
+	t, a := len(chain.Transient), len(chain.Absorbing)
+	iq := Identity(t)
+	r := New(t, a)
+
+	for i, si := range chain.Transient {
+		for j, sj := range chain.Transient {
+			iq.Set(i+1, j+1, iq.Get(i+1, j+1).Add(p.Get(si, sj).Neg()))
+		}
+
+		for j, sj := range chain.Absorbing {
+			r.Set(i+1, j+1, p.Get(si, sj))
+		}
+	}
+
+	n, err := Inverse(iq)
+	if err != nil {
+		return chain, errors.New("some transient state cannot reach an absorbing state")
+	}
+
+	ones := New(t, 1)
+	for i := 1; i <= t; i++ {
+		ones.Set(i, 1, NewScalarFrac(1))
+	}
+
+	chain.Fundamental = n
+	chain.Steps, _ = Multiply(n, ones)
+	chain.Probabilities, _ = Multiply(n, r)
+
+	return chain, nil
+}
@@ -0,0 +1,140 @@
+package matrix
+
+import "testing"
+
+func TestStochastic(t *testing.T) {
+	rows := manualMatrix([][]string{
+		{"1/2", "1/2"},
+		{"1/4", "3/4"},
+	})
+
+	if byRows, err := Stochastic(rows); err != nil || !byRows {
+		t.Errorf("Rows summing to one must be recognized, but got %v (error %v)", byRows, err)
+	}
+
+	if byRows, err := Stochastic(Transpose(rows)); err != nil || byRows {
+		t.Errorf("Columns summing to one must be recognized, but got %v (error %v)", byRows, err)
+	}
+
+	bad := manualMatrix([][]string{
+		{"1/2", "1/3"},
+		{"1/4", "3/4"},
+	})
+
+	if _, err := Stochastic(bad); err == nil {
+		t.Error("A matrix whose rows and columns do not sum to one must be rejected!")
+	}
+}
+
+func TestSteadyState(t *testing.T) {
+	p := manualMatrix([][]string{
+		{"1/2", "1/2"},
+		{"1/4", "3/4"},
+	})
+
+	expected := manualMatrix([][]string{
+		{"1/3", "2/3"},
+	})
+
+	res, err := SteadyState(p)
+	if err != nil {
+		t.Fatalf("Got error during steady state calculation: %v", err)
+	}
+
+	if !matrixEquals(res, expected) {
+		t.Errorf("Incorrect steady state! Wanted\n %v but got\n %v", expected, res)
+	}
+
+	if _, err := SteadyState(Identity(2)); err == nil {
+		t.Error("A chain with two stationary distributions must be rejected!")
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	p := manualMatrix([][]string{
+		{"1/2", "1/2"},
+		{"1/4", "3/4"},
+	})
+
+	x := manualMatrix([][]string{
+		{"1", "0"},
+	})
+
+	expected := manualMatrix([][]string{
+		{"3/8", "5/8"},
+	})
+
+	res, err := Distribution(p, x, 2)
+	if err != nil {
+		t.Fatalf("Got error during distribution calculation: %v", err)
+	}
+
+	if !matrixEquals(res, expected) {
+		t.Errorf("Incorrect distribution! Wanted\n %v but got\n %v", expected, res)
+	}
+
+	if _, err := Distribution(p, x, 60); err == nil {
+		t.Error("A distribution whose denominators overflow must be rejected!")
+	}
+
+	q := manualMatrix([][]string{
+		{"1/3", "2/3"},
+		{"1/5", "4/5"},
+	})
+
+	if _, err := Distribution(q, x, 40); err == nil {
+		t.Error("A distribution whose denominators overflow must be rejected!")
+	}
+}
+
+func TestAbsorb(t *testing.T) {
+	//A random walk on 0..3 which stops at either end, with states 1 and 4 absorbing.
+	p := manualMatrix([][]string{
+		{"1", "0", "0", "0"},
+		{"1/2", "0", "1/2", "0"},
+		{"0", "1/2", "0", "1/2"},
+		{"0", "0", "0", "1"},
+	})
+
+	chain, err := Absorb(p)
+	if err != nil {
+		t.Fatalf("Got error during absorption analysis: %v", err)
+	}
+
+	fundamental := manualMatrix([][]string{
+		{"4/3", "2/3"},
+		{"2/3", "4/3"},
+	})
+
+	steps := manualMatrix([][]string{
+		{"2"},
+		{"2"},
+	})
+
+	probabilities := manualMatrix([][]string{
+		{"2/3", "1/3"},
+		{"1/3", "2/3"},
+	})
+
+	if !matrixEquals(chain.Fundamental, fundamental) {
+		t.Errorf("Incorrect fundamental matrix! Wanted\n %v but got\n %v", fundamental, chain.Fundamental)
+	}
+
+	if !matrixEquals(chain.Steps, steps) {
+		t.Errorf("Incorrect expected steps! Wanted\n %v but got\n %v", steps, chain.Steps)
+	}
+
+	if !matrixEquals(chain.Probabilities, probabilities) {
+		t.Errorf("Incorrect absorption probabilities! Wanted\n %v but got\n %v", probabilities, chain.Probabilities)
+	}
+
+	cycle := manualMatrix([][]string{
+		{"1", "0", "0"},
+		{"0", "0", "1"},
+		{"0", "1", "0"},
+	})
+
+	if _, err := Absorb(cycle); err == nil {
+		t.Error("Transient states which cannot be absorbed must be rejected!")
+	}
+}
@@ -1,8 +1,8 @@
 package matrix
 
 import (
-	"bytes"
 	"errors"
+	"math"
 	"strconv"
 	"strings"
 )
@@ -137,11 +137,71 @@ func (f Frac) Reciprocal() Frac {
 	return f1
 }
 
+//Inv returns the multiplicative inverse of the fraction. It is the same as Reciprocal.
+func (f Frac) Inv() Frac {
+	return f.Reciprocal()
+}
+
+//Zero returns the fraction zero.
+func (f Frac) Zero() Frac {
+	return NewScalarFrac(0)
+}
+
+//One returns the fraction one.
+func (f Frac) One() Frac {
+	return NewScalarFrac(1)
+}
+
 //Neg negates the fraction (multiplies it by -1).
 func (f Frac) Neg() Frac {
 	return Frac{n: f.n * -1, d: f.d}
 }
 
+//mulChecked multiplies two fractions and returns the product in lowest terms.
+//The bool return value is false if the product does not fit in a fraction.
+func (f1 Frac) mulChecked(f2 Frac) (Frac, bool) {
+	g1, g2 := gcd(f1.n, f2.d), gcd(f2.n, f1.d)
+
+	n, nok := mulInts(f1.n/g1, f2.n/g2)
+	d, dok := mulInts(f1.d/g2, f2.d/g1)
+
+	return Frac{n: n, d: d}.Reduce(), nok && dok
+}
+
+//addChecked adds two fractions and returns the sum in lowest terms.
+//The bool return value is false if the sum does not fit in a fraction.
+func (f1 Frac) addChecked(f2 Frac) (Frac, bool) {
+	//Working over the least common denominator keeps sums of probabilities such as 1/2^40 + 1/2^40 in range.
+	g := gcd(f1.d, f2.d)
+
+	d, dok := mulInts(f1.d/g, f2.d)
+	a, aok := mulInts(f1.n, f2.d/g)
+	b, bok := mulInts(f2.n, f1.d/g)
+	n, nok := addInts(a, b)
+
+	return Frac{n: n, d: d}.Reduce(), aok && bok && dok && nok
+}
+
+func mulInts(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	if a == math.MinInt || b == math.MinInt {
+		return 0, false
+	}
+
+	p := a * b
+
+	return p, p/b == a
+}
+
+func addInts(a, b int) (int, bool) {
+	s := a + b
+
+	return s, !(a > 0 && b > 0 && s < 0) && !(a < 0 && b < 0 && s >= 0)
+}
+
 func gcd(i1, i2 int) int {
 	for i2 != 0 {
 		t := i2
@@ -167,342 +227,165 @@ func (f Frac) Reduce() Frac {
 	return f1
 }
 
-//M represents a matrix.
-type M struct {
-	//Number of rows and columns.
-	r, c int
-
-	//The values of the matrix, in row order.
-	values []Frac
-}
+//M represents a matrix of fractions.
+type M = Mat[Frac]
 
 //NewWithValues returns a new matrix with initial values vals.
 func NewWithValues(r, c int, vals []Frac) M {
-	return M{r: r, c: c, values: vals}
+	return M{grid: grid[Frac]{r: r, c: c, values: vals}, zero: NewScalarFrac(0)}
 }
 
 //New returns a zero matrix of size r,c.
 func New(r, c int) M {
-	vals := make([]Frac, r*c)
-	for i := range vals {
-		vals[i] = NewScalarFrac(0)
-	}
-
-	return M{r: r, c: c, values: vals}
-}
-
-//Equals returns true if the two matrices are equivalent, and false otherwise.
-func (m M) Equals(m1 M) bool {
-	if m.Cols() != m1.Cols() || m.Rows() != m1.Rows() {
-		return false
-	}
-
-	for r := 1; r <= m.Rows(); r++ {
-		for c := 1; c <= m.Cols(); c++ {
-			if !m.Get(r, c).Equals(m1.Get(r, c)) {
-				return false
-			}
-		}
-	}
-
-	return true
+	return NewMat(r, c, NewScalarFrac(0))
 }
 
-//String returns a string representation of the matrix.
-func (m M) String() string {
-	var buff bytes.Buffer
-
-	buff.WriteString("┌  ")
-	for c := 1; c < m.Cols(); c++ {
-		buff.WriteString(" \t")
-	}
-	buff.WriteString("  ┐\n")
-	for r := 1; r <= m.Rows(); r++ {
-		buff.WriteString("│ ")
-		buff.WriteString(m.Get(r, 1).String())
-		for c := 2; c <= m.Cols(); c++ {
-			buff.WriteString("\t")
-			buff.WriteString(m.Get(r, c).String())
-		}
-		buff.WriteString(" │\n")
-	}
-	buff.WriteString("└  ")
-	for c := 1; c < m.Cols(); c++ {
-		buff.WriteString(" \t")
-	}
-	buff.WriteString("  ┘")
-
-	return buff.String()
+//CopyMatrix creates a copy of the matrix, with same contents and size.
+func CopyMatrix(m M) M {
+	return CopyMat(m)
 }
 
-//Rows returns the number of rows in the matrix.
-func (m M) Rows() int {
-	return m.r
+//Transpose takes a copy of a matrix and returns its transpose.
+func Transpose(m M) M {
+	return TransposeMat(m)
 }
 
-//Cols returns the number of columns in the matrix.
-func (m M) Cols() int {
-	return m.c
+//Ref takes a copy of a matrix and returns itself in row echelon form.
+func Ref(m M) M {
+	return RefMat(m)
 }
 
-//Get returns the value at the specified row and column.
-func (m M) Get(r, c int) Frac {
-	r, c = r-1, c-1
-	return m.values[r*m.c+c]
+//Rref takes a copy of a matrix and returns it in rrrrrrreduced rrrrow echelon-a forrrrm-a!
+func Rref(m M) M {
+	return RrefMat(m)
 }
 
-//Set sets the value at the specified row and column to the given fraction.
-func (m *M) Set(r, c int, v Frac) {
-	r, c = r-1, c-1
-	m.values[r*m.c+c] = v.Reduce()
+//Inverse takes a copy of a matrix and returns its inverse.
+//An error is returned if the matrix has no inverse.
+func Inverse(m M) (M, error) {
+	return InverseMat(m)
 }
 
-//SwitchRows switches two rows. It is an elementary row operation.
-func (m *M) SwitchRows(r1, r2 int) {
-	r1, r2 = r1-1, r2-1
-	for c := 0; c < m.c; c++ {
-		tmp := m.values[r1*m.c+c]
-		m.values[r1*m.c+c] = m.values[r2*m.c+c]
-		m.values[r2*m.c+c] = tmp
-	}
+//Determinant returns the determinant of a matrix.
+//An error is returned if the matrix is not square.
+func Determinant(m M) (Frac, error) {
+	return DeterminantMat(m)
 }
 
-//MultiplyRow multiplies row r by the scalar s. It is an elementary row operation.
-func (m *M) MultiplyRow(r int, s Frac) {
-	r = r - 1
-	for c := 0; c < m.c; c++ {
-		m.values[r*m.c+c] = m.values[r*m.c+c].Mul(s).Reduce()
-	}
+//NullSpace returns a matrix whose columns form a basis for the null space of m.
+//If the null space is trivial, the zero vector is returned.
+func NullSpace(m M) M {
+	return NullSpaceMat(m)
 }
 
-//MultiplyAndAddRow adds row r1 multiplied by scalar s to row r2. It is an elementary row operation.
-func (m *M) MultiplyAndAddRow(r1 int, s Frac, r2 int) {
-	r1, r2 = r1-1, r2-1
-	for c := 0; c < m.c; c++ {
-		m.values[r2*m.c+c] = m.values[r2*m.c+c].Add(m.values[r1*m.c+c].Mul(s)).Reduce()
-	}
+//Identity returns the identity matrix of size i.
+func Identity(i int) M {
+	return IdentityMat(i, NewScalarFrac(0))
 }
 
-//CopyMatrix creates a copy of the matrix, with same contents and size.
-func CopyMatrix(m M) M {
-	mm := M{r: m.r, c: m.c, values: make([]Frac, len(m.values))}
-	copy(mm.values, m.values)
-
-	return mm
+//Augment augments a with b then returns this matrix.
+//It returns an error if the two matrices do not have the same number of rows.
+func Augment(a, b M) (M, error) {
+	return AugmentMat(a, b)
 }
 
-//Transpose takes a copy of a matrix and returns its transpose.
-func Transpose(m M) M {
-	rm := M{r: m.c, c: m.r, values: make([]Frac, m.c*m.r)}
-
-	for r := 1; r <= m.r; r++ {
-		for c := 1; c <= m.c; c++ {
-			rm.Set(c, r, m.Get(r, c))
-		}
-	}
-
-	return rm
+//Add adds matrix a to matrix b.
+//It returns an error if a and b are not the same size.
+func Add(a, b M) (M, error) {
+	return AddMat(a, b)
 }
 
-func isLeadingEntry(m M, r, c int) bool {
-	if m.Get(r, c).IsZero() {
-		return false // must not be zero to be a leading entry
-	}
-
-	for cc := c - 1; cc > 0; cc-- {
-		if !m.Get(r, cc).IsZero() {
-			return false
-		}
-	}
-
-	return true
+//Scale scales a matrix by a... you guessed it... scalar. It returns this new matrix.
+func Scale(s Frac, m M) M {
+	return ScaleMat(s, m)
 }
 
-//Ref takes a copy of a matrix and returns itself in row echelon form.
-func Ref(m M) M {
-	m = CopyMatrix(m)
-
-	startr := 1
-	for c := 1; c <= m.Cols(); c++ { // find a leading entry in this column
-		found := false
-		for r := startr; r <= m.Rows(); r++ {
-			if isLeadingEntry(m, r, c) {
-				found = true
-				m.SwitchRows(startr, r) // move it to the top, son!
-				break
-			}
-		}
-
-		if !found { // no leading entry, next column please
-			continue
-		}
-
-		m.MultiplyRow(startr, m.Get(startr, c).Reciprocal()) // make first entry one
-
-		for r := startr + 1; r <= m.Rows(); r++ {
-			if isLeadingEntry(m, r, c) {
-				m.MultiplyAndAddRow(startr, m.Get(startr, c).Reciprocal().Mul(m.Get(r, c).Neg()), r) // zero first column entry
-			}
-		}
-
-		startr++ // row is now in ref
-	}
-
-	return m
+//Multiply multiplies a by b. If the matrices cannot be multiplied, an error is returned.
+func Multiply(a, b M) (M, error) {
+	return MultiplyMat(a, b)
 }
 
-//Rref takes a copy of a matrix and returns it in rrrrrrreduced rrrrow echelon-a forrrrm-a!
-func Rref(m M) M {
-	m = CopyMatrix(m)
-
-	m = Ref(m)
-
-	for c := 1; c <= m.Cols(); c++ {
-		for r := 1; r <= m.Rows(); r++ {
-			if isLeadingEntry(m, r, c) {
-				m.MultiplyRow(r, m.Get(r, c).Reciprocal()) // make the leading entry 1
-				for rr := r - 1; rr > 0; rr-- {            // for each row above the current row...
-					if !m.Get(rr, c).IsZero() {
-						m.MultiplyAndAddRow(r, m.Get(rr, c).Neg().Mul(m.Get(r, c).Reciprocal()), rr) // clear entry above leading entry
-					}
-				}
-			}
-		}
-	}
-
-	return m
+//Power raises a square matrix to the integer power k. Negative powers are powers of the inverse.
+//An error is returned if the matrix is not square, or if k is negative and the matrix has no inverse.
+func Power(m M, k int) (M, error) {
+	return PowerMat(m, k)
 }
 
-//Inverse takes a copy of a matrix and returns its inverse.
-//An error is returned if the matrix has no inverse.
-func Inverse(m M) (M, error) {
-	m = CopyMatrix(m)
+//errOverflow is returned by the checked computations when an exact result no longer fits in a fraction.
+var errOverflow = errors.New("the numbers grew too large to be represented exactly")
 
+//PowerChecked raises a square matrix to the non-negative integer power k like Power, but returns an error
+//instead of a wrong result if an entry grows too large to be represented exactly.
+func PowerChecked(m M, k int) (M, error) {
 	if m.Rows() != m.Cols() {
-		return m, errors.New("non-square matrices have no inverse")
+		return m, errors.New("only square matrices can be raised to a power")
 	}
 
-	m, _ = Augment(m, Identity(m.r)) // ignore error because Identity will always match m row size
-
-	m = Rref(m)
-
-	for c := 1; c <= m.Rows(); c++ { // must be square, so m.Rows() works here
-		found := false
-		for r := 1; r <= m.Rows(); r++ {
-			if isLeadingEntry(m, r, c) && m.Get(r, c).Numerator() == 1 && m.Get(r, c).Denominator() == 1 { //Leading entry that is one
-				found = true
-				break
-			}
-		}
-
-		if !found { //no leading entry that is one... not the identity, not invertible :(
-			return m, errors.New("matrix has no inverse")
-		}
+	if k < 0 {
+		return m, errors.New("the power must not be negative")
 	}
 
-	rm := M{r: m.r, c: m.r, values: make([]Frac, m.r*m.r)}
+	rm := Identity(m.Rows())
+	ok := true
 
-	for r := 1; r <= rm.Rows(); r++ {
-		for c := 1; c <= rm.Cols(); c++ {
-			rm.Set(r, c, m.Get(r, rm.Cols()+c))
+	for ; k > 0; k /= 2 {
+		if k%2 == 1 {
+			if rm, ok = multiplyChecked(rm, m); !ok {
+				return rm, errOverflow
+			}
 		}
-	}
-
-	return rm, nil
-}
 
-//Identity returns the identity matrix of size i.
-func Identity(i int) M {
-	rm := M{r: i, c: i, values: make([]Frac, i*i)}
-
-	for r := 1; r <= rm.Rows(); r++ {
-		for c := 1; c <= rm.Cols(); c++ {
-			if r == c {
-				rm.Set(r, c, NewScalarFrac(1))
-			} else {
-				rm.Set(r, c, NewScalarFrac(0))
+		//The last square is never used, and may overflow when the result does not.
+		if k > 1 {
+			if m, ok = multiplyChecked(m, m); !ok {
+				return m, errOverflow
 			}
 		}
 	}
 
-	return rm
+	return rm, nil
 }
 
-//Augment augments a with b then returns this matrix.
-//It returns an error if the two matrices do not have the same number of rows.
-func Augment(a, b M) (M, error) {
-	if a.r != b.r {
-		return a, errors.New("augmented matrices must have equal row counts")
-	}
-
-	rm := M{r: a.r, c: a.c + b.c}
-
-	rm.values = make([]Frac, rm.r*rm.c)
-
-	for r := 1; r <= a.Rows(); r++ {
-		for c := 1; c <= a.Cols(); c++ {
-			rm.Set(r, c, a.Get(r, c))
-		}
-	}
+//multiplyChecked returns the product of a and b, whose sizes must match.
+//The bool return value is false if an entry of the product does not fit in a fraction.
+func multiplyChecked(a, b M) (M, bool) {
+	rm := New(a.Rows(), b.Cols())
 
 	for r := 1; r <= a.Rows(); r++ {
 		for c := 1; c <= b.Cols(); c++ {
-			rm.Set(r, a.Cols()+c, b.Get(r, c))
-		}
-	}
-
-	return rm, nil
-}
+			sum := NewScalarFrac(0)
 
-//Add adds matrix a to matrix b.
-//It returns an error if a and b are not the same size.
-func Add(a, b M) (M, error) {
-	if a.r != b.r || a.c != b.c {
-		return a, errors.New("addition requires two identically-sized matrices")
-	}
+			for i := 1; i <= a.Cols(); i++ {
+				p, ok := a.Get(r, i).mulChecked(b.Get(i, c))
+				if !ok {
+					return rm, false
+				}
 
-	rm := CopyMatrix(b)
+				if sum, ok = sum.addChecked(p); !ok {
+					return rm, false
+				}
+			}
 
-	for r := 1; r <= rm.Rows(); r++ {
-		for c := 1; c <= rm.Cols(); c++ {
-			rm.Set(r, c, rm.Get(r, c).Add(a.Get(r, c)))
+			rm.Set(r, c, sum)
 		}
 	}
 
-	return rm, nil
-}
-
-//Scale scales a matrix by a... you guessed it... scalar. It returns this new matrix.
-func Scale(s Frac, m M) M {
-	m = CopyMatrix(m)
-
-	for r := 1; r <= m.Rows(); r++ {
-		m.MultiplyRow(r, s)
-	}
-
-	return m
+	return rm, true
 }
 
-//Multiply multiplies a by b. If the matrices cannot be multiplied, an error is returned.
-func Multiply(a, b M) (M, error) {
-	if a.c != b.r {
-		return a, errors.New("multiplication can only be done on matrices A and B if the number of columns of A equals the number of rows of B")
+//ElementaryFactors expresses an invertible matrix as a product of elementary matrices. It returns the row operations
+//which reduce m to the identity, in order. If they are E1, E2, ..., Ek, then Ek⋯E2E1m = I, so m = E1⁻¹E2⁻¹⋯Ek⁻¹.
+//An error is returned if the matrix has no inverse.
+func ElementaryFactors(m M) ([]RowOp[Frac], error) {
+	if m.Rows() != m.Cols() {
+		return nil, errors.New("non-square matrices have no inverse")
 	}
 
-	rm := M{r: a.r, c: b.c, values: make([]Frac, a.r*b.c)}
+	rref, ops := RrefOps(m)
 
-	for r := 1; r <= rm.Rows(); r++ {
-		for c := 1; c <= rm.Cols(); c++ {
-			sum := NewScalarFrac(0)
-			for count := 1; count <= a.c; count++ {
-				f1 := a.Get(r, count)
-				f2 := b.Get(count, c)
-				res := f1.Mul(f2)
-				sum = sum.Add(res)
-			}
-			rm.Set(r, c, sum)
-		}
+	if !rref.Equals(Identity(m.Rows())) {
+		return nil, errors.New("matrix has no inverse")
 	}
 
-	return rm, nil
+	return ops, nil
 }
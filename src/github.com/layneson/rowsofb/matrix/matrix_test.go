@@ -337,3 +337,140 @@ func TestMult(t *testing.T) {
 		}
 	}
 }
+
+func TestDeterminant(t *testing.T) {
+	tests := [][]interface{}{
+		{manualMatrix([][]string{
+			{"2", "6", "8"},
+			{"6", "18", "25"},
+			{"6", "17", "32"},
+		}), NewScalarFrac(2)},
+
+		{manualMatrix([][]string{
+			{"0", "1"},
+			{"1/2", "3"},
+		}), NewFrac(-1, 2)},
+
+		{manualMatrix([][]string{
+			{"1", "2", "3"},
+			{"4", "5", "6"},
+			{"7", "8", "9"},
+		}), NewScalarFrac(0)},
+
+		{Identity(4), NewScalarFrac(1)},
+	}
+
+	for _, tst := range tests {
+		m := tst[0].(M)
+		expected := tst[1].(Frac)
+
+		det, err := Determinant(m)
+		if err != nil {
+			t.Errorf("Got error during determinant calculation: %v", err)
+		}
+		if !fractionEquals(det, expected) {
+			t.Errorf("Incorrect determinant! Wanted %v but got %v", expected, det)
+		}
+	}
+
+	if _, err := Determinant(New(2, 3)); err == nil {
+		t.Error("Non-square matrix must have no determinant!")
+	}
+}
+
+func TestNullSpace(t *testing.T) {
+	tests := [][]M{
+		{manualMatrix([][]string{
+			{"1", "2", "3"},
+			{"4", "5", "6"},
+			{"7", "8", "9"},
+		}), manualMatrix([][]string{
+			{"1"},
+			{"-2"},
+			{"1"},
+		})},
+
+		{manualMatrix([][]string{
+			{"1", "2", "0", "1"},
+		}), manualMatrix([][]string{
+			{"-2", "0", "-1"},
+			{"1", "0", "0"},
+			{"0", "1", "0"},
+			{"0", "0", "1"},
+		})},
+
+		{Identity(2), New(2, 1)},
+	}
+
+	for _, tst := range tests {
+		res := NullSpace(tst[0])
+		if !matrixEquals(res, tst[1]) {
+			t.Errorf("Incorrect null space! Wanted\n %v but got\n %v", tst[1], res)
+		}
+	}
+}
+
+func TestPower(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"1", "1"},
+		{"0", "1"},
+	})
+
+	res, err := Power(input, 5)
+	if err != nil {
+		t.Fatalf("Got error raising matrix to a power: %v", err)
+	}
+
+	expected := manualMatrix([][]string{
+		{"1", "5"},
+		{"0", "1"},
+	})
+
+	if !matrixEquals(res, expected) {
+		t.Errorf("Incorrect matrix power! Wanted\n %v but got\n %v", expected, res)
+	}
+
+	res, _ = Power(input, -2)
+
+	expected = manualMatrix([][]string{
+		{"1", "-2"},
+		{"0", "1"},
+	})
+
+	if !matrixEquals(res, expected) {
+		t.Errorf("Incorrect negative matrix power! Wanted\n %v but got\n %v", expected, res)
+	}
+
+	if _, err := Power(New(2, 2), -1); err == nil {
+		t.Error("Negative powers of a singular matrix must fail!")
+	}
+}
+
+func TestElementaryFactors(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"0", "2"},
+		{"3", "4"},
+	})
+
+	ops, err := ElementaryFactors(input)
+	if err != nil {
+		t.Fatalf("Got error during elementary factorization: %v", err)
+	}
+
+	product := Identity(2)
+	for _, op := range ops {
+		product, _ = Multiply(product, op.Inverse().Elementary(2))
+	}
+
+	if !matrixEquals(product, input) {
+		t.Errorf("Product of elementary factors should be\n %v but was\n %v", input, product)
+	}
+
+	if ops[0].Kind != SwitchOp || ops[0].String() != "R1 ↔ R2" {
+		t.Errorf("First row operation should be R1 ↔ R2 but was %v", ops[0])
+	}
+
+	if _, err := ElementaryFactors(New(2, 2)); err == nil {
+		t.Error("Singular matrices must have no elementary factorization!")
+	}
+}
@@ -0,0 +1,309 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+//IsPrime returns true if p is a prime number, and false otherwise.
+func IsPrime(p int) bool {
+	if p < 2 {
+		return false
+	}
+
+	for i := 2; i*i <= p; i++ {
+		if p%i == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+//modInverse returns the multiplicative inverse of a modulo n using the extended Euclidean algorithm.
+//The bool return value is false if a has no inverse modulo n.
+func modInverse(a, n int) (int, bool) {
+	a = mod(a, n)
+
+	t, nt := 0, 1
+	r, nr := n, a
+	for nr != 0 {
+		q := r / nr
+		t, nt = nt, t-q*nt
+		r, nr = nr, r-q*nr
+	}
+
+	if r != 1 {
+		return 0, false
+	}
+
+	return mod(t, n), true
+}
+
+//mod returns a modulo n, always in the range [0, n).
+func mod(a, n int) int {
+	a %= n
+	if a < 0 {
+		a += n
+	}
+
+	return a
+}
+
+//Mod returns the residue of the fraction modulo n as a whole-number fraction in the range [0, n).
+//The denominator is replaced by its inverse modulo n, so an error is returned if it has no such inverse.
+func (f Frac) Mod(n int) (Frac, error) {
+	f = f.Reduce()
+
+	dinv, ok := modInverse(f.d, n)
+	if !ok {
+		return Frac{}, fmt.Errorf("%v has no value modulo %d", f, n)
+	}
+
+	return NewScalarFrac(mod(mod(f.n, n)*dinv, n)), nil
+}
+
+//ModInverse returns the multiplicative inverse of the fraction modulo n.
+//An error is returned if no such inverse exists.
+func (f Frac) ModInverse(n int) (Frac, error) {
+	f, err := f.Mod(n)
+	if err != nil {
+		return f, err
+	}
+
+	inv, ok := modInverse(f.n, n)
+	if !ok {
+		return Frac{}, fmt.Errorf("%v has no inverse modulo %d", f, n)
+	}
+
+	return NewScalarFrac(inv), nil
+}
+
+//ReduceMod takes a copy of a matrix and reduces every entry modulo n.
+//An error is returned if an entry has no value modulo n.
+func ReduceMod(m M, n int) (M, error) {
+	m = CopyMatrix(m)
+
+	for i, f := range m.values {
+		rf, err := f.Mod(n)
+		if err != nil {
+			return m, err
+		}
+
+		m.values[i] = rf
+	}
+
+	return m, nil
+}
+
+func checkPrime(p int) error {
+	if !IsPrime(p) {
+		return fmt.Errorf("%d is not prime", p)
+	}
+
+	return nil
+}
+
+//ModInt is a residue modulo a prime p, an element of the finite field of integers modulo p.
+type ModInt struct {
+	v, p int
+}
+
+//NewModInt returns the residue of v modulo the prime p.
+func NewModInt(v, p int) ModInt {
+	return ModInt{v: mod(v, p), p: p}
+}
+
+//Value returns the residue as an integer in the range [0, p).
+func (a ModInt) Value() int {
+	return a.v
+}
+
+//Add adds two residues.
+func (a ModInt) Add(b ModInt) ModInt {
+	return NewModInt(a.v+b.v, a.p)
+}
+
+//Mul multiplies two residues.
+func (a ModInt) Mul(b ModInt) ModInt {
+	return NewModInt(a.v*b.v, a.p)
+}
+
+//Neg returns the additive inverse of the residue.
+func (a ModInt) Neg() ModInt {
+	return NewModInt(-a.v, a.p)
+}
+
+//Inv returns the multiplicative inverse of the residue, which exists for every nonzero residue since p is prime.
+func (a ModInt) Inv() ModInt {
+	inv, _ := modInverse(a.v, a.p)
+	return ModInt{v: inv, p: a.p}
+}
+
+//IsZero returns true if the residue is zero.
+func (a ModInt) IsZero() bool {
+	return a.v == 0
+}
+
+//Zero returns the residue zero modulo the same prime.
+func (a ModInt) Zero() ModInt {
+	return ModInt{v: 0, p: a.p}
+}
+
+//One returns the residue one modulo the same prime.
+func (a ModInt) One() ModInt {
+	return NewModInt(1, a.p)
+}
+
+//String returns a string representation of the residue.
+func (a ModInt) String() string {
+	return strconv.Itoa(a.v)
+}
+
+//toModMat converts a matrix of fractions to a matrix of residues modulo the prime p.
+//An error is returned if p is not prime or if an entry has no value modulo p.
+func toModMat(m M, p int) (Mat[ModInt], error) {
+	if err := checkPrime(p); err != nil {
+		return Mat[ModInt]{}, err
+	}
+
+	rm := NewMat(m.Rows(), m.Cols(), NewModInt(0, p))
+
+	for r := 1; r <= m.Rows(); r++ {
+		for c := 1; c <= m.Cols(); c++ {
+			f, err := m.Get(r, c).Mod(p)
+			if err != nil {
+				return rm, err
+			}
+
+			rm.Set(r, c, NewModInt(f.Numerator(), p))
+		}
+	}
+
+	return rm, nil
+}
+
+//fromModMat converts a matrix of residues to a matrix of whole-number fractions.
+func fromModMat(m Mat[ModInt]) M {
+	rm := New(m.Rows(), m.Cols())
+
+	for r := 1; r <= m.Rows(); r++ {
+		for c := 1; c <= m.Cols(); c++ {
+			rm.Set(r, c, NewScalarFrac(m.Get(r, c).Value()))
+		}
+	}
+
+	return rm
+}
+
+//RefMod takes a copy of a matrix and returns it in row echelon form over the field of integers modulo the prime p.
+//An error is returned if p is not prime or if an entry has no value modulo p.
+func RefMod(m M, p int) (M, error) {
+	mm, err := toModMat(m, p)
+	if err != nil {
+		return m, err
+	}
+
+	return fromModMat(RefMat(mm)), nil
+}
+
+//RrefMod takes a copy of a matrix and returns it in reduced row echelon form over the field of integers modulo the prime p.
+//An error is returned if p is not prime or if an entry has no value modulo p.
+func RrefMod(m M, p int) (M, error) {
+	mm, err := toModMat(m, p)
+	if err != nil {
+		return m, err
+	}
+
+	return fromModMat(RrefMat(mm)), nil
+}
+
+//InverseMod takes a copy of a matrix and returns its inverse over the field of integers modulo the prime p.
+//An error is returned if p is not prime or if the matrix has no inverse modulo p.
+func InverseMod(m M, p int) (M, error) {
+	if m.Rows() != m.Cols() {
+		return m, errors.New("non-square matrices have no inverse")
+	}
+
+	mm, err := toModMat(m, p)
+	if err != nil {
+		return m, err
+	}
+
+	mm, err = InverseMat(mm)
+	if err != nil {
+		return m, fmt.Errorf("matrix has no inverse modulo %d", p)
+	}
+
+	return fromModMat(mm), nil
+}
+
+//InverseModN takes a copy of a matrix and returns its inverse over the integers modulo n, which need not be prime.
+//The inverse is the adjugate multiplied by the inverse of the determinant modulo n, so an error is returned if the
+//determinant shares a factor with n or if an entry has no value modulo n.
+func InverseModN(m M, n int) (M, error) {
+	rm, d, dinv, err := unitDeterminant(m, n)
+	if err != nil {
+		return m, err
+	}
+
+	//The determinant is nonzero, so the inverse exists, and the adjugate det·A⁻¹ has integer entries.
+	inv, _ := Inverse(rm)
+
+	return ReduceMod(Scale(NewScalarFrac(d*dinv), inv), n)
+}
+
+//unitDeterminant reduces m modulo n and returns it along with its determinant d and the inverse of d modulo n.
+//An error is returned if m has no inverse modulo n, for the reasons given by InverseModN.
+func unitDeterminant(m M, n int) (M, int, int, error) {
+	if m.Rows() != m.Cols() {
+		return m, 0, 0, errors.New("non-square matrices have no inverse")
+	}
+
+	if n < 2 {
+		return m, 0, 0, fmt.Errorf("%d is not a valid modulus", n)
+	}
+
+	rm, err := ReduceMod(m, n)
+	if err != nil {
+		return m, 0, 0, err
+	}
+
+	det, _ := Determinant(rm)
+	d := det.Reduce().Integer()
+
+	dinv, ok := modInverse(d, n)
+	if !ok {
+		return m, 0, 0, fmt.Errorf("the determinant %d has gcd(%d, %d) = %d, so the matrix has no inverse modulo %d", d, d, n, gcd(n, mod(d, n)), n)
+	}
+
+	return rm, d, dinv, nil
+}
+
+//DeterminantMod returns the determinant of a matrix over the field of integers modulo the prime p.
+//An error is returned if p is not prime, if the matrix is not square or if an entry has no value modulo p.
+func DeterminantMod(m M, p int) (Frac, error) {
+	mm, err := toModMat(m, p)
+	if err != nil {
+		return Frac{}, err
+	}
+
+	det, err := DeterminantMat(mm)
+	if err != nil {
+		return Frac{}, err
+	}
+
+	return NewScalarFrac(det.Value()), nil
+}
+
+//NullSpaceMod returns a matrix whose columns form a basis for the null space of m over the field of integers modulo the prime p.
+//If the null space is trivial, the zero vector is returned.
+func NullSpaceMod(m M, p int) (M, error) {
+	mm, err := toModMat(m, p)
+	if err != nil {
+		return m, err
+	}
+
+	return fromModMat(NullSpaceMat(mm)), nil
+}
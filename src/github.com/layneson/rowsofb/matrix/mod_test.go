@@ -0,0 +1,166 @@
+package matrix
+
+import "testing"
+
+func TestIsPrime(t *testing.T) {
+	tests := map[int]bool{
+		-7: false,
+		0:  false,
+		1:  false,
+		2:  true,
+		7:  true,
+		9:  false,
+		26: false,
+		97: true,
+	}
+
+	for p, expected := range tests {
+		if IsPrime(p) != expected {
+			t.Errorf("IsPrime(%d) should be %t", p, expected)
+		}
+	}
+}
+
+func TestFracMod(t *testing.T) {
+	tests := [][]Frac{
+		{NewScalarFrac(10), NewScalarFrac(3)},
+		{NewScalarFrac(-1), NewScalarFrac(6)},
+		{NewFrac(1, 2), NewScalarFrac(4)},
+		{NewFrac(-2, 3), NewScalarFrac(4)},
+	}
+
+	for _, tst := range tests {
+		res, err := tst[0].Mod(7)
+		if err != nil {
+			t.Errorf("Got error reducing %v modulo 7: %v", tst[0], err)
+		}
+		if !fractionEquals(res, tst[1]) {
+			t.Errorf("%v modulo 7 should be %v but was %v", tst[0], tst[1], res)
+		}
+	}
+
+	if _, err := NewFrac(1, 7).Mod(7); err == nil {
+		t.Error("1/7 must have no value modulo 7!")
+	}
+}
+
+func TestRrefMod(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"1", "2", "3"},
+		{"4", "5", "6"},
+		{"0", "1", "7"},
+	})
+
+	res, err := RrefMod(input, 5)
+	if err != nil {
+		t.Fatalf("Got error during modular row reduction: %v", err)
+	}
+
+	expected := manualMatrix([][]string{
+		{"1", "0", "4"},
+		{"0", "1", "2"},
+		{"0", "0", "0"},
+	})
+
+	if !matrixEquals(res, expected) {
+		t.Errorf("Incorrect modular row reduction result! Wanted\n %v but got\n %v", expected, res)
+	}
+
+	if _, err := RrefMod(input, 6); err == nil {
+		t.Error("Row reduction modulo 6 must fail!")
+	}
+}
+
+func TestInverseMod(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"2", "6", "8"},
+		{"6", "18", "25"},
+		{"6", "17", "32"},
+	})
+
+	res, err := InverseMod(input, 7)
+	if err != nil {
+		t.Fatalf("Got error during modular inverse calculation: %v", err)
+	}
+
+	product, _ := Multiply(input, res)
+	product, _ = ReduceMod(product, 7)
+
+	if !matrixEquals(product, Identity(3)) {
+		t.Errorf("Incorrect modular inverse! Got\n %v", res)
+	}
+
+	if _, err := InverseMod(input, 2); err == nil {
+		t.Error("Matrix must have no inverse modulo 2!")
+	}
+}
+
+func TestDeterminantMod(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"2", "6", "8"},
+		{"6", "18", "25"},
+		{"6", "17", "32"},
+	})
+
+	det, err := DeterminantMod(input, 5)
+	if err != nil {
+		t.Fatalf("Got error during modular determinant calculation: %v", err)
+	}
+
+	if !fractionEquals(det, NewScalarFrac(2)) {
+		t.Errorf("Determinant modulo 5 should be 2 but was %v", det)
+	}
+}
+
+func TestNullSpaceMod(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"1", "2", "3"},
+		{"4", "5", "6"},
+		{"0", "1", "7"},
+	})
+
+	res, err := NullSpaceMod(input, 5)
+	if err != nil {
+		t.Fatalf("Got error during modular null space calculation: %v", err)
+	}
+
+	expected := manualMatrix([][]string{
+		{"1"},
+		{"3"},
+		{"1"},
+	})
+
+	if !matrixEquals(res, expected) {
+		t.Errorf("Incorrect modular null space! Wanted\n %v but got\n %v", expected, res)
+	}
+}
+
+func TestInverseModN(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"3", "3"},
+		{"2", "5"},
+	})
+
+	res, err := InverseModN(input, 26)
+	if err != nil {
+		t.Fatalf("Got error during inversion modulo 26: %v", err)
+	}
+
+	expected := manualMatrix([][]string{
+		{"15", "17"},
+		{"20", "9"},
+	})
+
+	if !matrixEquals(res, expected) {
+		t.Errorf("Incorrect inverse modulo 26! Wanted\n %v but got\n %v", expected, res)
+	}
+
+	singular := manualMatrix([][]string{
+		{"2", "0"},
+		{"0", "1"},
+	})
+
+	if _, err := InverseModN(singular, 26); err == nil {
+		t.Error("A matrix with determinant 2 should have no inverse modulo 26")
+	}
+}
@@ -0,0 +1,315 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+)
+
+//ExpTerm is the part of a vector function of t belonging to one exponential: e^{Rate·t} times the polynomial
+//Coeffs[0] + t·Coeffs[1] + t²·Coeffs[2] + ..., whose coefficients are column vectors.
+type ExpTerm struct {
+	Rate   Frac
+	Coeffs []M
+}
+
+//PairTerm is the part of a vector function of t belonging to a pair of eigenvalues u ± s which are not rational, where
+//u is Rate and s² is Delta. It is e^{ut}(C(t)·Even + S(t)·Odd), with C(t) = cosh(st) and S(t) = sinh(st)/s. When Delta is
+//negative, s is the imaginary number βi, and these are cos(βt) and sin(βt)/β. Since C' = Delta·S and S' = C, both are real
+//and Even and Odd are vectors of fractions.
+type PairTerm struct {
+	Rate, Delta Frac
+	Even, Odd   M
+}
+
+//Root returns √|Delta|: the surd s of the real eigenvalues u ± s, or the β of the complex eigenvalues u ± βi.
+func (p PairTerm) Root() Radical {
+	if p.Delta.Numerator() < 0 {
+		r, _ := SqrtFrac(p.Delta.Neg())
+		return r
+	}
+
+	r, _ := SqrtFrac(p.Delta)
+	return r
+}
+
+//Eigenvalues writes the pair of eigenvalues, such as "1/2 ± (1/2)√5" or "-1 ± 2i".
+func (p PairTerm) Eigenvalues() string {
+	s := p.Root().String()
+	if p.Delta.Numerator() < 0 {
+		if s == "1" {
+			s = ""
+		}
+
+		s += "i"
+	}
+
+	if p.Rate.IsZero() {
+		return "±" + s
+	}
+
+	return p.Rate.String() + " ± " + s
+}
+
+//ExpVector is a column vector whose entries are functions of t, each a sum of polynomials times exponentials.
+//It is stored by exponential, with the terms in increasing order of rate and no two terms sharing a rate.
+//Eigenvalues which are not rational contribute pair terms instead.
+type ExpVector struct {
+	n     int
+	Terms []ExpTerm
+	Pairs []PairTerm
+}
+
+//Rows returns the number of entries in the vector.
+func (v ExpVector) Rows() int {
+	return v.n
+}
+
+//Entry returns entry i of the vector as the rates of its exponentials and the polynomial in t multiplying each one.
+//Exponentials whose polynomial is zero are left out.
+func (v ExpVector) Entry(i int) ([]Frac, []Poly) {
+	rates, polys := []Frac{}, []Poly{}
+
+	for _, term := range v.Terms {
+		coeffs := make([]Frac, len(term.Coeffs))
+		for j, c := range term.Coeffs {
+			coeffs[j] = c.Get(i, 1)
+		}
+
+		if p := NewPoly(coeffs...); !p.IsZero() {
+			rates = append(rates, term.Rate)
+			polys = append(polys, p)
+		}
+	}
+
+	return rates, polys
+}
+
+//AtZero returns the value of the vector at t = 0.
+func (v ExpVector) AtZero() M {
+	rm := New(v.n, 1)
+
+	for _, term := range v.Terms {
+		if len(term.Coeffs) > 0 {
+			rm, _ = Add(rm, term.Coeffs[0])
+		}
+	}
+
+	//C(0) = 1 and S(0) = 0.
+	for _, pair := range v.Pairs {
+		rm, _ = Add(rm, pair.Even)
+	}
+
+	return rm
+}
+
+//Scale multiplies every entry of the vector by a fraction.
+func (v ExpVector) Scale(f Frac) ExpVector {
+	rv := ExpVector{n: v.n}
+
+	for _, term := range v.Terms {
+		st := ExpTerm{Rate: term.Rate}
+		for _, c := range term.Coeffs {
+			st.Coeffs = append(st.Coeffs, Scale(f, c))
+		}
+
+		rv.Terms = append(rv.Terms, st)
+	}
+
+	for _, pair := range v.Pairs {
+		rv.Pairs = append(rv.Pairs, PairTerm{Rate: pair.Rate, Delta: pair.Delta, Even: Scale(f, pair.Even), Odd: Scale(f, pair.Odd)})
+	}
+
+	return rv
+}
+
+//Derivative returns the derivative of the vector with respect to t. The derivative of e^{λt}t^j is e^{λt}(λt^j + jt^{j-1}).
+func (v ExpVector) Derivative() ExpVector {
+	rv := ExpVector{n: v.n}
+
+	for _, term := range v.Terms {
+		dt := ExpTerm{Rate: term.Rate}
+		for j, c := range term.Coeffs {
+			d := Scale(term.Rate, c)
+			if j+1 < len(term.Coeffs) {
+				d, _ = Add(d, Scale(NewScalarFrac(j+1), term.Coeffs[j+1]))
+			}
+
+			dt.Coeffs = append(dt.Coeffs, d)
+		}
+
+		rv.Terms = append(rv.Terms, dt)
+	}
+
+	//The derivative of e^{ut}(C·Even + S·Odd) is e^{ut}(C·(u·Even + Odd) + S·(u·Odd + Delta·Even)).
+	for _, pair := range v.Pairs {
+		even, _ := Add(Scale(pair.Rate, pair.Even), pair.Odd)
+		odd, _ := Add(Scale(pair.Rate, pair.Odd), Scale(pair.Delta, pair.Even))
+
+		rv.Pairs = append(rv.Pairs, PairTerm{Rate: pair.Rate, Delta: pair.Delta, Even: even, Odd: odd})
+	}
+
+	return rv
+}
+
+//MultiplyExp returns the vector a·v. An error is returned if the number of columns of a does not match the size of v.
+func MultiplyExp(a M, v ExpVector) (ExpVector, error) {
+	if a.Cols() != v.n {
+		return ExpVector{}, fmt.Errorf("cannot multiply a %dx%d matrix by a vector of %d entries", a.Rows(), a.Cols(), v.n)
+	}
+
+	rv := ExpVector{n: a.Rows()}
+
+	for _, term := range v.Terms {
+		pt := ExpTerm{Rate: term.Rate}
+		for _, c := range term.Coeffs {
+			p, _ := Multiply(a, c)
+			pt.Coeffs = append(pt.Coeffs, p)
+		}
+
+		rv.Terms = append(rv.Terms, pt)
+	}
+
+	for _, pair := range v.Pairs {
+		even, _ := Multiply(a, pair.Even)
+		odd, _ := Multiply(a, pair.Odd)
+
+		rv.Pairs = append(rv.Pairs, PairTerm{Rate: pair.Rate, Delta: pair.Delta, Even: even, Odd: odd})
+	}
+
+	return rv, nil
+}
+
+//Equals returns true if the two vectors are the same function of t.
+func (v ExpVector) Equals(w ExpVector) bool {
+	if v.n != w.n || len(v.Pairs) != len(w.Pairs) {
+		return false
+	}
+
+	for i, pair := range v.Pairs {
+		other := w.Pairs[i]
+		if !pair.Rate.Equals(other.Rate) || !pair.Delta.Equals(other.Delta) || !pair.Even.Equals(other.Even) || !pair.Odd.Equals(other.Odd) {
+			return false
+		}
+	}
+
+	for i := 1; i <= v.n; i++ {
+		vr, vp := v.Entry(i)
+		wr, wp := w.Entry(i)
+
+		if len(vr) != len(wr) {
+			return false
+		}
+
+		for j := range vr {
+			if !vr[j].Equals(wr[j]) || !vp[j].Equals(wp[j]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+//SolveODE returns the solution x(t) = e^{At}x0 of the system x' = Ax with x(0) = x0. The initial vector is split into its parts
+//in the generalized eigenspaces of A, the null spaces of (A - λI)^m for each eigenvalue λ of algebraic multiplicity m.
+//The part v belonging to λ contributes e^{λt}(v + t(A - λI)v + (t²/2)(A - λI)²v + ...), which stops once the Jordan chain
+//of v ends, so an eigenvector contributes only e^{λt}v.
+//An irreducible quadratic factor x² + bx + c of the characteristic polynomial gives the eigenvalues u ± s, where u = -b/2 and
+//s² = u² - c, which are quadratic surds or complex. The part w of x0 in the null space of A² + bA + cI contributes the pair term
+//e^{ut}(C(t)·w + S(t)·(A - uI)w), since (A - uI)² = s² there.
+//An error is returned if A is not square, if x0 does not match it, or if the eigenvalues of A are not all rational apart from
+//at most one such pair.
+func SolveODE(a, x0 M) (ExpVector, error) {
+	n := a.Rows()
+	if n != a.Cols() {
+		return ExpVector{}, errors.New("the system x' = Ax needs a square matrix A")
+	}
+
+	init, err := VectorEntries(x0)
+	if err != nil {
+		return ExpVector{}, err
+	}
+
+	if len(init) != n {
+		return ExpVector{}, fmt.Errorf("the initial vector has %d entries, but A is %dx%d", len(init), n, n)
+	}
+
+	cp, _ := CharPoly(a)
+	roots, mults, rest := cp.SplitRationalRoots()
+
+	//What is left is monic with no rational roots: nothing, or an irreducible quadratic.
+	pair := rest.Degree() == 2
+	if rest.Degree() > 0 && !pair {
+		return ExpVector{}, fmt.Errorf("the characteristic polynomial has the factor %v, whose roots are not rational, quadratic surds or a complex pair", rest)
+	}
+
+	shifted := make([]M, len(roots))
+	bases := make([]M, len(roots))
+	for i, r := range roots {
+		shifted[i], _ = Add(a, Scale(r.Neg(), Identity(n)))
+
+		p, _ := Power(shifted[i], mults[i])
+		bases[i] = NullSpace(p)
+	}
+
+	if pair {
+		q, _ := rest.EvalMatrix(a)
+		bases = append(bases, NullSpace(q))
+	}
+
+	//The generalized eigenspaces together span every vector, so the coordinates of x0 in their bases are unique.
+	b, _ := Block([][]M{bases})
+	binv, err := Inverse(b)
+	if err != nil {
+		return ExpVector{}, errors.New("the generalized eigenvectors do not span every vector")
+	}
+
+	x := New(n, 1)
+	for i, f := range init {
+		x.Set(i+1, 1, f)
+	}
+
+	coords, _ := Multiply(binv, x)
+
+	sol := ExpVector{n: n}
+	zero := New(n, 1)
+	row := 1
+	for i, r := range roots {
+		c, _ := Submatrix(coords, row, row+bases[i].Cols()-1, 1, 1)
+		row += bases[i].Cols()
+
+		v, _ := Multiply(bases[i], c)
+
+		term := ExpTerm{Rate: r}
+		fact := 1
+		for j := 0; j < mults[i] && !v.Equals(zero); j++ {
+			if j > 0 {
+				fact *= j
+			}
+
+			term.Coeffs = append(term.Coeffs, Scale(NewFrac(1, fact), v))
+			v, _ = Multiply(shifted[i], v)
+		}
+
+		if len(term.Coeffs) > 0 {
+			sol.Terms = append(sol.Terms, term)
+		}
+	}
+
+	if pair {
+		c, _ := Submatrix(coords, row, n, 1, 1)
+		w, _ := Multiply(bases[len(roots)], c)
+
+		if !w.Equals(zero) {
+			u := rest.Coeff(1).Mul(NewFrac(-1, 2)).Reduce()
+			delta := u.Mul(u).Add(rest.Coeff(0).Neg()).Reduce()
+
+			shift, _ := Add(a, Scale(u.Neg(), Identity(n)))
+			odd, _ := Multiply(shift, w)
+
+			sol.Pairs = append(sol.Pairs, PairTerm{Rate: u, Delta: delta, Even: w, Odd: odd})
+		}
+	}
+
+	return sol, nil
+}
@@ -0,0 +1,124 @@
+package matrix
+
+import "testing"
+
+func TestSolveODE(t *testing.T) {
+	tests := []struct {
+		a, x0 [][]string
+		rates []int
+	}{
+		{[][]string{{"2", "0"}, {"0", "-1"}}, [][]string{{"3"}, {"4"}}, []int{-1, 2}},
+		{[][]string{{"1", "2"}, {"2", "1"}}, [][]string{{"1"}, {"0"}}, []int{-1, 3}},
+		{[][]string{{"1", "1"}, {"0", "1"}}, [][]string{{"1"}, {"1"}}, []int{1}},
+		{[][]string{{"2", "1", "0"}, {"0", "2", "1"}, {"0", "0", "2"}}, [][]string{{"0"}, {"0"}, {"1"}}, []int{2}},
+		{[][]string{{"3", "1", "0"}, {"0", "3", "0"}, {"0", "0", "-2"}}, [][]string{{"1"}, {"1"}, {"1"}}, []int{-2, 3}},
+	}
+
+	for _, test := range tests {
+		a, x0 := manualMatrix(test.a), manualMatrix(test.x0)
+
+		x, err := SolveODE(a, x0)
+		if err != nil {
+			t.Fatalf("Got error while solving x' = Ax for A =\n %v: %v", a, err)
+		}
+
+		if !matrixEquals(x.AtZero(), x0) {
+			t.Errorf("The solution for A =\n %v should start at\n %v but starts at\n %v", a, x0, x.AtZero())
+		}
+
+		ax, _ := MultiplyExp(a, x)
+		if !x.Derivative().Equals(ax) {
+			t.Errorf("The solution for A =\n %v does not satisfy x' = Ax", a)
+		}
+
+		if len(x.Terms) != len(test.rates) {
+			t.Fatalf("The solution for A =\n %v should have %d exponentials but has %d", a, len(test.rates), len(x.Terms))
+		}
+
+		for i, r := range test.rates {
+			if !x.Terms[i].Rate.Equals(NewScalarFrac(r)) {
+				t.Errorf("Exponential %d of the solution for A =\n %v should be e^%dt, not e^(%v)t", i+1, a, r, x.Terms[i].Rate)
+			}
+		}
+	}
+}
+
+func TestSolveODEDefective(t *testing.T) {
+	a := manualMatrix([][]string{{"2", "1", "0"}, {"0", "2", "1"}, {"0", "0", "2"}})
+	x0 := manualMatrix([][]string{{"0"}, {"0"}, {"1"}})
+
+	x, _ := SolveODE(a, x0)
+
+	//x(t) = e^{2t}(t²/2, t, 1)
+	expected := []Poly{
+		NewPoly(NewScalarFrac(0), NewScalarFrac(0), NewFrac(1, 2)),
+		NewPoly(NewScalarFrac(0), NewScalarFrac(1)),
+		NewPoly(NewScalarFrac(1)),
+	}
+
+	for i, p := range expected {
+		rates, polys := x.Entry(i + 1)
+		if len(polys) != 1 || !rates[0].Equals(NewScalarFrac(2)) || !polys[0].Equals(p) {
+			t.Errorf("Entry %d of the solution should be (%v)e^2t, but has rates %v and polynomials %v", i+1, p, rates, polys)
+		}
+	}
+}
+
+func TestSolveODEPair(t *testing.T) {
+	tests := []struct {
+		a, x0       [][]string
+		rate, delta int
+		rates       []int
+	}{
+		//x² + 1: x(t) = (cos t, -sin t)
+		{[][]string{{"0", "1"}, {"-1", "0"}}, [][]string{{"1"}, {"0"}}, 0, -1, nil},
+		//x² - 2: the eigenvalues ±√2
+		{[][]string{{"0", "1"}, {"2", "0"}}, [][]string{{"1"}, {"0"}}, 0, 2, nil},
+		//(x - 3)(x² - 2x + 3): the eigenvalues 3 and 1 ± √2i
+		{[][]string{{"1", "-2", "0"}, {"1", "1", "0"}, {"0", "0", "3"}}, [][]string{{"1"}, {"1"}, {"1"}}, 1, -2, []int{3}},
+	}
+
+	for _, test := range tests {
+		a, x0 := manualMatrix(test.a), manualMatrix(test.x0)
+
+		x, err := SolveODE(a, x0)
+		if err != nil {
+			t.Fatalf("Got error while solving x' = Ax for A =\n %v: %v", a, err)
+		}
+
+		if !matrixEquals(x.AtZero(), x0) {
+			t.Errorf("The solution for A =\n %v should start at\n %v but starts at\n %v", a, x0, x.AtZero())
+		}
+
+		ax, _ := MultiplyExp(a, x)
+		if !x.Derivative().Equals(ax) {
+			t.Errorf("The solution for A =\n %v does not satisfy x' = Ax", a)
+		}
+
+		if len(x.Pairs) != 1 || !fractionEquals(x.Pairs[0].Rate, NewScalarFrac(test.rate)) || !fractionEquals(x.Pairs[0].Delta, NewScalarFrac(test.delta)) {
+			t.Fatalf("The solution for A =\n %v should have one pair term with u = %d and s² = %d, but has %v", a, test.rate, test.delta, x.Pairs)
+		}
+
+		if len(x.Terms) != len(test.rates) {
+			t.Fatalf("The solution for A =\n %v should have %d exponentials but has %d", a, len(test.rates), len(x.Terms))
+		}
+	}
+
+	rotation := manualMatrix([][]string{{"0", "1"}, {"-1", "0"}})
+	x, _ := SolveODE(rotation, manualMatrix([][]string{{"1"}, {"0"}}))
+
+	//C(t) = cos t and S(t) = sin t, so x(t) = (cos t, -sin t).
+	even, odd := manualMatrix([][]string{{"1"}, {"0"}}), manualMatrix([][]string{{"0"}, {"-1"}})
+	if !matrixEquals(x.Pairs[0].Even, even) || !matrixEquals(x.Pairs[0].Odd, odd) {
+		t.Errorf("The rotation should give cos t times\n %v and sin t times\n %v, but gave\n %v and\n %v", even, odd, x.Pairs[0].Even, x.Pairs[0].Odd)
+	}
+
+	if e := x.Pairs[0].Eigenvalues(); e != "±i" {
+		t.Errorf("The eigenvalues of a rotation should be ±i, not %s", e)
+	}
+
+	quartic := manualMatrix([][]string{{"0", "1", "0", "0"}, {"0", "0", "1", "0"}, {"0", "0", "0", "1"}, {"-1", "0", "0", "0"}})
+	if _, err := SolveODE(quartic, manualMatrix([][]string{{"1"}, {"0"}, {"0"}, {"0"}})); err == nil {
+		t.Error("The factor x⁴ + 1 has no rational or quadratic roots, so solving with it should fail")
+	}
+}
@@ -0,0 +1,587 @@
+package matrix
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+)
+
+//Poly represents a polynomial in x with fractional coefficients.
+type Poly struct {
+	//Coefficients, starting with the constant term. The last coefficient is never zero.
+	coeffs []Frac
+}
+
+//NewPoly returns a polynomial with the given coefficients, starting with the constant term.
+func NewPoly(coeffs ...Frac) Poly {
+	p := Poly{coeffs: make([]Frac, len(coeffs))}
+	for i, f := range coeffs {
+		p.coeffs[i] = f.Reduce()
+	}
+
+	p.trim()
+
+	return p
+}
+
+//NewScalarPoly returns a constant polynomial.
+func NewScalarPoly(f Frac) Poly {
+	return NewPoly(f)
+}
+
+//PolyX returns the polynomial x.
+func PolyX() Poly {
+	return NewPoly(NewScalarFrac(0), NewScalarFrac(1))
+}
+
+//trim removes zero leading coefficients. It is the only method that mutates Poly.
+func (p *Poly) trim() {
+	for len(p.coeffs) > 0 && p.coeffs[len(p.coeffs)-1].IsZero() {
+		p.coeffs = p.coeffs[:len(p.coeffs)-1]
+	}
+}
+
+//Degree returns the degree of the polynomial. The zero polynomial has degree -1.
+func (p Poly) Degree() int {
+	return len(p.coeffs) - 1
+}
+
+//Coeff returns the coefficient of x^i.
+func (p Poly) Coeff(i int) Frac {
+	if i < 0 || i >= len(p.coeffs) {
+		return NewScalarFrac(0)
+	}
+
+	return p.coeffs[i]
+}
+
+//Lead returns the leading coefficient of the polynomial, which is zero only for the zero polynomial.
+func (p Poly) Lead() Frac {
+	return p.Coeff(p.Degree())
+}
+
+//IsZero returns true if the polynomial is the zero polynomial.
+func (p Poly) IsZero() bool {
+	return len(p.coeffs) == 0
+}
+
+//IsConstant returns true if the polynomial has no x terms.
+func (p Poly) IsConstant() bool {
+	return p.Degree() <= 0
+}
+
+//Equals returns true if the two polynomials are equivalent, and false otherwise.
+func (p Poly) Equals(p1 Poly) bool {
+	if p.Degree() != p1.Degree() {
+		return false
+	}
+
+	for i := range p.coeffs {
+		if !p.coeffs[i].Equals(p1.coeffs[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+//Add adds two polynomials and returns the result.
+func (p1 Poly) Add(p2 Poly) Poly {
+	n := len(p1.coeffs)
+	if len(p2.coeffs) > n {
+		n = len(p2.coeffs)
+	}
+
+	coeffs := make([]Frac, n)
+	for i := range coeffs {
+		coeffs[i] = p1.Coeff(i).Add(p2.Coeff(i))
+	}
+
+	return NewPoly(coeffs...)
+}
+
+//Neg negates the polynomial.
+func (p Poly) Neg() Poly {
+	return p.Scale(NewScalarFrac(-1))
+}
+
+//Scale multiplies every coefficient of the polynomial by s.
+func (p Poly) Scale(s Frac) Poly {
+	coeffs := make([]Frac, len(p.coeffs))
+	for i, f := range p.coeffs {
+		coeffs[i] = f.Mul(s)
+	}
+
+	return NewPoly(coeffs...)
+}
+
+//Mul multiplies two polynomials and returns the result.
+func (p1 Poly) Mul(p2 Poly) Poly {
+	if p1.IsZero() || p2.IsZero() {
+		return Poly{}
+	}
+
+	coeffs := make([]Frac, len(p1.coeffs)+len(p2.coeffs)-1)
+	for i := range coeffs {
+		coeffs[i] = NewScalarFrac(0)
+	}
+
+	for i, f1 := range p1.coeffs {
+		for j, f2 := range p2.coeffs {
+			coeffs[i+j] = coeffs[i+j].Add(f1.Mul(f2)).Reduce()
+		}
+	}
+
+	return NewPoly(coeffs...)
+}
+
+//DivMod divides p1 by p2, returning the quotient and remainder.
+//It panics if p2 is the zero polynomial.
+func (p1 Poly) DivMod(p2 Poly) (Poly, Poly) {
+	if p2.IsZero() {
+		panic("Division by the zero polynomial!")
+	}
+
+	q := make([]Frac, 0)
+	if p1.Degree() >= p2.Degree() {
+		q = make([]Frac, p1.Degree()-p2.Degree()+1)
+	}
+	for i := range q {
+		q[i] = NewScalarFrac(0)
+	}
+
+	rem := p1
+	for !rem.IsZero() && rem.Degree() >= p2.Degree() {
+		shift := rem.Degree() - p2.Degree()
+		f := rem.Lead().Div(p2.Lead()).Reduce()
+		q[shift] = f
+
+		term := make([]Frac, shift+1)
+		for i := range term {
+			term[i] = NewScalarFrac(0)
+		}
+		term[shift] = f
+
+		rem = rem.Add(p2.Mul(NewPoly(term...)).Neg())
+	}
+
+	return NewPoly(q...), rem
+}
+
+//Eval evaluates the polynomial at x = f.
+func (p Poly) Eval(f Frac) Frac {
+	res := NewScalarFrac(0)
+	for i := p.Degree(); i >= 0; i-- {
+		res = res.Mul(f).Add(p.coeffs[i]).Reduce()
+	}
+
+	return res
+}
+
+//Mod reduces every coefficient of the polynomial modulo n.
+//An error is returned if a coefficient has no value modulo n.
+func (p Poly) Mod(n int) (Poly, error) {
+	coeffs := make([]Frac, len(p.coeffs))
+	for i, f := range p.coeffs {
+		rf, err := f.Mod(n)
+		if err != nil {
+			return p, err
+		}
+
+		coeffs[i] = rf
+	}
+
+	return NewPoly(coeffs...), nil
+}
+
+//Monic returns the polynomial divided by its leading coefficient. The zero polynomial is returned unchanged.
+func (p Poly) Monic() Poly {
+	if p.IsZero() {
+		return p
+	}
+
+	return p.Scale(p.Lead().Reciprocal())
+}
+
+//PolyGCD returns the monic greatest common divisor of two polynomials.
+//The result is the zero polynomial only if both polynomials are zero.
+func PolyGCD(p1, p2 Poly) Poly {
+	for !p2.IsZero() {
+		_, r := p1.DivMod(p2)
+		p1, p2 = p2, r
+	}
+
+	return p1.Monic()
+}
+
+//RationalRoots returns the distinct rational roots of the polynomial in increasing order.
+//It uses the rational root theorem, so it is only practical for polynomials with small coefficients.
+func (p Poly) RationalRoots() []Frac {
+	roots := []Frac{}
+
+	if p.IsConstant() {
+		return roots
+	}
+
+	//Scale to integer coefficients.
+	l := 1
+	for _, f := range p.coeffs {
+		l = l / gcd(l, f.Reduce().d) * f.Reduce().d
+	}
+	p = p.Scale(NewScalarFrac(l))
+
+	//Zero is a root if the constant term vanishes; divide out the x factors so the constant term is nonzero.
+	low := 0
+	for p.coeffs[low].IsZero() {
+		low++
+	}
+	if low > 0 {
+		roots = append(roots, NewScalarFrac(0))
+		p = NewPoly(p.coeffs[low:]...)
+	}
+
+	if p.IsConstant() {
+		return roots
+	}
+
+	for _, n := range divisors(p.coeffs[0].Numerator()) {
+		for _, d := range divisors(p.Lead().Numerator()) {
+			for _, f := range []Frac{NewFrac(n, d), NewFrac(-n, d)} {
+				f = f.Reduce()
+				if !p.Eval(f).IsZero() || containsFrac(roots, f) {
+					continue
+				}
+
+				roots = append(roots, f)
+			}
+		}
+	}
+
+	sortFracs(roots)
+
+	return roots
+}
+
+//SplitRationalRoots returns the distinct rational roots of the polynomial in increasing order with their multiplicities,
+//along with what is left after dividing out each factor x - r as often as it divides.
+func (p Poly) SplitRationalRoots() ([]Frac, []int, Poly) {
+	roots := p.RationalRoots()
+	mults := make([]int, len(roots))
+
+	for i, r := range roots {
+		lin := NewPoly(r.Neg(), NewScalarFrac(1))
+
+		for {
+			q, rem := p.DivMod(lin)
+			if !rem.IsZero() {
+				break
+			}
+
+			p = q
+			mults[i]++
+		}
+	}
+
+	return roots, mults, p
+}
+
+func divisors(n int) []int {
+	if n < 0 {
+		n = -n
+	}
+
+	divs := []int{}
+	for i := 1; i*i <= n; i++ {
+		if n%i == 0 {
+			divs = append(divs, i)
+			if i*i != n {
+				divs = append(divs, n/i)
+			}
+		}
+	}
+
+	return divs
+}
+
+func containsFrac(fracs []Frac, f Frac) bool {
+	for _, ff := range fracs {
+		if ff.Equals(f) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//sortFracs sorts the fractions in increasing order using insertion sort.
+func sortFracs(fracs []Frac) {
+	for i := 1; i < len(fracs); i++ {
+		for j := i; j > 0 && fracs[j].Add(fracs[j-1].Neg()).Numerator() < 0; j-- {
+			fracs[j], fracs[j-1] = fracs[j-1], fracs[j]
+		}
+	}
+}
+
+//String returns a string representation of the polynomial, such as "x^2 - 3x + 1/2".
+func (p Poly) String() string {
+	return p.StringIn('x')
+}
+
+//StringIn returns a string representation of the polynomial using v as the variable name.
+func (p Poly) StringIn(v rune) string {
+	if p.IsZero() {
+		return "0"
+	}
+
+	var buff bytes.Buffer
+
+	for i := p.Degree(); i >= 0; i-- {
+		f := p.coeffs[i]
+		if f.IsZero() {
+			continue
+		}
+
+		if i == p.Degree() {
+			if f.Numerator() < 0 {
+				buff.WriteString("-")
+			}
+		} else if f.Numerator() < 0 {
+			buff.WriteString(" - ")
+		} else {
+			buff.WriteString(" + ")
+		}
+
+		abs := f
+		if f.Numerator() < 0 {
+			abs = f.Neg()
+		}
+
+		if i == 0 {
+			buff.WriteString(abs.String())
+			continue
+		}
+
+		if !abs.Equals(NewScalarFrac(1)) {
+			if abs.IsWhole() {
+				buff.WriteString(abs.String())
+			} else {
+				buff.WriteString("(" + abs.String() + ")")
+			}
+		}
+
+		buff.WriteRune(v)
+		if i > 1 {
+			buff.WriteString("^" + strconv.Itoa(i))
+		}
+	}
+
+	return buff.String()
+}
+
+//PM represents a matrix with polynomial entries.
+type PM struct {
+	grid[Poly]
+}
+
+//NewPM returns a zero polynomial matrix of size r,c.
+func NewPM(r, c int) PM {
+	return PM{newGrid(r, c, Poly{})}
+}
+
+//PMFromMatrix returns a polynomial matrix with the constant entries of m.
+func PMFromMatrix(m M) PM {
+	pm := NewPM(m.Rows(), m.Cols())
+
+	for r := 1; r <= m.Rows(); r++ {
+		for c := 1; c <= m.Cols(); c++ {
+			pm.Set(r, c, NewScalarPoly(m.Get(r, c)))
+		}
+	}
+
+	return pm
+}
+
+//Equals returns true if the two matrices are equivalent, and false otherwise.
+func (m PM) Equals(m1 PM) bool {
+	return equalGrid(m.grid, m1.grid, Poly.Equals)
+}
+
+//CopyPM creates a copy of the polynomial matrix, with same contents and size.
+func CopyPM(m PM) PM {
+	return PM{copyGrid(m.grid)}
+}
+
+//ReduceModPM takes a copy of a polynomial matrix and reduces the coefficients of every entry modulo n.
+//An error is returned if a coefficient has no value modulo n.
+func ReduceModPM(m PM, n int) (PM, error) {
+	m = CopyPM(m)
+
+	for i, p := range m.values {
+		rp, err := p.Mod(n)
+		if err != nil {
+			return m, err
+		}
+
+		m.values[i] = rp
+	}
+
+	return m, nil
+}
+
+//AddPM adds polynomial matrix a to polynomial matrix b.
+//It returns an error if a and b are not the same size.
+func AddPM(a, b PM) (PM, error) {
+	g, err := addGrid(a.grid, b.grid, Poly.Add)
+
+	return PM{g}, err
+}
+
+//ScalePM multiplies every entry of a polynomial matrix by the polynomial s.
+func ScalePM(s Poly, m PM) PM {
+	return PM{mapGrid(m.grid, s.Mul)}
+}
+
+//MultiplyPM multiplies polynomial matrix a by b. If the matrices cannot be multiplied, an error is returned.
+func MultiplyPM(a, b PM) (PM, error) {
+	g, err := multiplyGrid(a.grid, b.grid, Poly{}, Poly.Add, Poly.Mul)
+
+	return PM{g}, err
+}
+
+//DeterminantPM returns the determinant of a polynomial matrix.
+//It uses fraction-free (Bareiss) elimination, so every division is exact.
+//An error is returned if the matrix is not square.
+func DeterminantPM(m PM) (Poly, error) {
+	if m.Rows() != m.Cols() {
+		return Poly{}, errors.New("non-square matrices have no determinant")
+	}
+
+	n := m.Rows()
+	if n == 0 {
+		return NewScalarPoly(NewScalarFrac(1)), nil
+	}
+
+	m = CopyPM(m)
+
+	sign := NewScalarFrac(1)
+	prev := NewScalarPoly(NewScalarFrac(1))
+
+	for k := 1; k < n; k++ {
+		if m.Get(k, k).IsZero() {
+			pivot := 0
+			for r := k + 1; r <= n; r++ {
+				if !m.Get(r, k).IsZero() {
+					pivot = r
+					break
+				}
+			}
+
+			if pivot == 0 {
+				return Poly{}, nil
+			}
+
+			m.switchRows(k, pivot)
+			sign = sign.Neg()
+		}
+
+		for r := k + 1; r <= n; r++ {
+			for c := k + 1; c <= n; c++ {
+				num := m.Get(r, c).Mul(m.Get(k, k)).Add(m.Get(r, k).Mul(m.Get(k, c)).Neg())
+				q, _ := num.DivMod(prev) // exact by Sylvester's identity
+				m.Set(r, c, q)
+			}
+		}
+
+		prev = m.Get(k, k)
+	}
+
+	return m.Get(n, n).Scale(sign), nil
+}
+
+//CharPoly returns the characteristic polynomial det(xI - m) of a square matrix.
+//An error is returned if the matrix is not square.
+func CharPoly(m M) (Poly, error) {
+	if m.Rows() != m.Cols() {
+		return Poly{}, errors.New("non-square matrices have no characteristic polynomial")
+	}
+
+	xi := ScalePM(PolyX(), PMFromMatrix(Identity(m.Rows())))
+	a := ScalePM(NewScalarPoly(NewScalarFrac(-1)), PMFromMatrix(m))
+
+	xia, _ := AddPM(xi, a)
+
+	return DeterminantPM(xia)
+}
+
+//EvalMatrix evaluates the polynomial at a square matrix using Horner's rule, with the constant term
+//standing for a multiple of the identity. An error is returned if the matrix is not square.
+func (p Poly) EvalMatrix(m M) (M, error) {
+	if m.Rows() != m.Cols() {
+		return m, errors.New("polynomials can only be evaluated at square matrices")
+	}
+
+	rm := New(m.Rows(), m.Cols())
+
+	for i := p.Degree(); i >= 0; i-- {
+		rm, _ = Multiply(rm, m)
+		rm, _ = Add(rm, Scale(p.Coeff(i), Identity(m.Rows())))
+	}
+
+	return rm, nil
+}
+
+//MinPoly returns the minimal polynomial of a square matrix: the monic polynomial of least degree which is zero at m.
+//It finds the first power of m which is a linear combination of the lower powers.
+//An error is returned if the matrix is not square.
+func MinPoly(m M) (Poly, error) {
+	if m.Rows() != m.Cols() {
+		return Poly{}, errors.New("non-square matrices have no minimal polynomial")
+	}
+
+	n := m.Rows()
+
+	// each column of powers holds one power of m, flattened in row order
+	powers := New(n*n, 0)
+	power := Identity(n)
+
+	for k := 0; ; k++ {
+		col := NewWithValues(n*n, 1, append([]Frac{}, power.values...))
+		powers, _ = Augment(powers, col)
+
+		null := NullSpace(powers)
+		if !null.Equals(New(k+1, 1)) { // the powers up to k are dependent, so the dependency must involve the k-th
+			coeffs := make([]Frac, k+1)
+			for i := range coeffs {
+				coeffs[i] = null.Get(i+1, 1)
+			}
+
+			return NewPoly(coeffs...).Monic(), nil
+		}
+
+		power, _ = Multiply(power, m)
+	}
+}
+
+//Companion returns the companion matrix of a monic polynomial: ones below the diagonal and the negated
+//coefficients, constant term first, down the last column. Its characteristic and minimal polynomials are both p.
+//The polynomial is made monic first; a constant polynomial has an empty companion matrix.
+func Companion(p Poly) M {
+	p = p.Monic()
+
+	n := p.Degree()
+	if n < 0 {
+		n = 0
+	}
+
+	rm := New(n, n)
+
+	for i := 1; i <= n; i++ {
+		if i > 1 {
+			rm.Set(i, i-1, NewScalarFrac(1))
+		}
+
+		rm.Set(i, n, p.Coeff(i-1).Neg())
+	}
+
+	return rm
+}
@@ -0,0 +1,177 @@
+package matrix
+
+import "testing"
+
+func manualPoly(coeffs ...int) Poly {
+	fracs := make([]Frac, len(coeffs))
+	for i, n := range coeffs {
+		fracs[i] = NewScalarFrac(n)
+	}
+
+	return NewPoly(fracs...)
+}
+
+func TestPolyMul(t *testing.T) {
+	tests := [][]Poly{
+		{manualPoly(1, 1), manualPoly(-1, 1), manualPoly(-1, 0, 1)},
+		{manualPoly(2), manualPoly(0, 3, 1), manualPoly(0, 6, 2)},
+		{manualPoly(), manualPoly(1, 2, 3), manualPoly()},
+	}
+
+	for _, tst := range tests {
+		res := tst[0].Mul(tst[1])
+		if !res.Equals(tst[2]) {
+			t.Errorf("(%v)(%v) should be %v but was %v", tst[0], tst[1], tst[2], res)
+		}
+	}
+}
+
+func TestPolyDivMod(t *testing.T) {
+	tests := [][]Poly{
+		{manualPoly(-1, 0, 1), manualPoly(-1, 1), manualPoly(1, 1), manualPoly()},
+		{manualPoly(1, 0, 1), manualPoly(0, 2), NewPoly(NewScalarFrac(0), NewFrac(1, 2)), manualPoly(1)},
+		{manualPoly(3), manualPoly(0, 1), manualPoly(), manualPoly(3)},
+	}
+
+	for _, tst := range tests {
+		q, r := tst[0].DivMod(tst[1])
+		if !q.Equals(tst[2]) || !r.Equals(tst[3]) {
+			t.Errorf("(%v)/(%v) should be %v rem %v but was %v rem %v", tst[0], tst[1], tst[2], tst[3], q, r)
+		}
+	}
+}
+
+func TestPolyString(t *testing.T) {
+	tests := map[string]Poly{
+		"0":                  manualPoly(),
+		"x":                  PolyX(),
+		"-x^2 + 1":           manualPoly(1, 0, -1),
+		"x^3 - 2x^2 - x + 5": manualPoly(5, -1, -2, 1),
+		"(1/2)x - 3/4":       NewPoly(NewFrac(-3, 4), NewFrac(1, 2)),
+	}
+
+	for expected, p := range tests {
+		if p.String() != expected {
+			t.Errorf("Polynomial should print as %q but printed as %q", expected, p.String())
+		}
+	}
+}
+
+func TestDeterminantPM(t *testing.T) {
+	m := manualMatrix([][]string{
+		{"2", "6", "8"},
+		{"6", "18", "25"},
+		{"6", "17", "32"},
+	})
+
+	det, err := DeterminantPM(PMFromMatrix(m))
+	if err != nil {
+		t.Fatalf("Got error during polynomial determinant calculation: %v", err)
+	}
+
+	if !det.Equals(manualPoly(2)) {
+		t.Errorf("Determinant should be 2 but was %v", det)
+	}
+
+	xm := ScalePM(PolyX(), PMFromMatrix(Identity(2)))
+	xm.Set(1, 2, manualPoly(1))
+
+	det, _ = DeterminantPM(xm)
+	if !det.Equals(manualPoly(0, 0, 1)) {
+		t.Errorf("Determinant should be x^2 but was %v", det)
+	}
+}
+
+func TestCharPoly(t *testing.T) {
+	tests := []struct {
+		m M
+		p Poly
+	}{
+		{manualMatrix([][]string{
+			{"1", "2"},
+			{"3", "4"},
+		}), manualPoly(-2, -5, 1)},
+
+		{manualMatrix([][]string{
+			{"0", "1", "0"},
+			{"0", "0", "1"},
+			{"6", "-11", "6"},
+		}), manualPoly(-6, 11, -6, 1)},
+
+		{manualMatrix([][]string{
+			{"0", "0"},
+			{"0", "0"},
+		}), manualPoly(0, 0, 1)},
+	}
+
+	for _, tst := range tests {
+		res, err := CharPoly(tst.m)
+		if err != nil {
+			t.Errorf("Got error during characteristic polynomial calculation: %v", err)
+		}
+		if !res.Equals(tst.p) {
+			t.Errorf("Characteristic polynomial should be %v but was %v", tst.p, res)
+		}
+	}
+}
+
+func TestMinPoly(t *testing.T) {
+	tests := []struct {
+		m M
+		p Poly
+	}{
+		{manualMatrix([][]string{
+			{"2", "0", "0"},
+			{"0", "2", "0"},
+			{"0", "0", "3"},
+		}), manualPoly(6, -5, 1)},
+
+		{manualMatrix([][]string{
+			{"2", "1"},
+			{"0", "2"},
+		}), manualPoly(4, -4, 1)},
+
+		{manualMatrix([][]string{
+			{"0", "0"},
+			{"0", "0"},
+		}), manualPoly(0, 1)},
+	}
+
+	for _, tst := range tests {
+		res, err := MinPoly(tst.m)
+		if err != nil {
+			t.Errorf("Got error during minimal polynomial calculation: %v", err)
+		}
+		if !res.Equals(tst.p) {
+			t.Errorf("Minimal polynomial should be %v but was %v", tst.p, res)
+		}
+	}
+}
+
+func TestCayleyHamilton(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"1", "2", "0"},
+		{"-1", "3", "4"},
+		{"2", "0", "5"},
+	})
+
+	p, _ := CharPoly(input)
+
+	res, err := p.EvalMatrix(input)
+	if err != nil {
+		t.Fatalf("Got error evaluating polynomial at matrix: %v", err)
+	}
+
+	if !matrixEquals(res, New(3, 3)) {
+		t.Errorf("Characteristic polynomial evaluated at its matrix should be zero but was\n %v", res)
+	}
+}
+
+func TestCompanion(t *testing.T) {
+	p := manualPoly(-6, 11, -6, 1)
+
+	res, _ := CharPoly(Companion(p))
+	if !res.Equals(p) {
+		t.Errorf("Characteristic polynomial of the companion matrix of %v was %v", p, res)
+	}
+}
@@ -0,0 +1,268 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//Definiteness classifies a quadratic form by the signs of the values it takes.
+type Definiteness int
+
+//Definiteness definitions
+const (
+	PositiveDefinite Definiteness = iota
+	PositiveSemidefinite
+	NegativeDefinite
+	NegativeSemidefinite
+	Indefinite
+	ZeroForm
+)
+
+func (d Definiteness) String() string {
+	switch d {
+	case PositiveDefinite:
+		return "positive definite"
+	case PositiveSemidefinite:
+		return "positive semidefinite"
+	case NegativeDefinite:
+		return "negative definite"
+	case NegativeSemidefinite:
+		return "negative semidefinite"
+	case Indefinite:
+		return "indefinite"
+	case ZeroForm:
+		return "identically zero"
+	}
+
+	return "unknown"
+}
+
+//CongruenceDiagonalize finds an invertible matrix P such that PᵀmP is diagonal, by performing each row operation of
+//symmetric elimination on the columns as well. Substituting x = Py into the quadratic form xᵀmx removes its cross terms.
+//An error is returned if m is not symmetric.
+func CongruenceDiagonalize(m M) (M, M, error) {
+	if err := checkSymmetric(m); err != nil {
+		return m, m, err
+	}
+
+	n := m.Rows()
+	a := CopyMatrix(m)
+	pt := Identity(n) // accumulates the row operations, so that P is its transpose
+
+	// op performs a row operation on pt and the same operation on both the rows and columns of a.
+	op := func(f func(*M)) {
+		f(&pt)
+		f(&a)
+		a = Transpose(a)
+		f(&a)
+		a = Transpose(a)
+	}
+
+	for k := 1; k <= n; k++ {
+		if a.Get(k, k).IsZero() {
+			for j := k + 1; j <= n; j++ {
+				if !a.Get(j, j).IsZero() {
+					op(func(mm *M) { mm.SwitchRows(k, j) })
+					break
+				}
+			}
+		}
+
+		if a.Get(k, k).IsZero() {
+			for j := k + 1; j <= n; j++ {
+				if !a.Get(k, j).IsZero() { // adding x_j to x_k makes the diagonal entry 2a_kj
+					op(func(mm *M) { mm.MultiplyAndAddRow(j, NewScalarFrac(1), k) })
+					break
+				}
+			}
+		}
+
+		if a.Get(k, k).IsZero() { // the whole row and column are zero
+			continue
+		}
+
+		for r := k + 1; r <= n; r++ {
+			if !a.Get(r, k).IsZero() {
+				mult := a.Get(r, k).Div(a.Get(k, k)).Neg()
+				op(func(mm *M) { mm.MultiplyAndAddRow(k, mult, r) })
+			}
+		}
+	}
+
+	return Transpose(pt), a, nil
+}
+
+//LeadingMinors returns the determinants of the leading principal submatrices of a square matrix, from 1x1 up to the whole matrix.
+func LeadingMinors(m M) []Frac {
+	minors := []Frac{}
+
+	for k := 1; k <= m.Rows() && k <= m.Cols(); k++ {
+		sub := New(k, k)
+		for r := 1; r <= k; r++ {
+			for c := 1; c <= k; c++ {
+				sub.Set(r, c, m.Get(r, c))
+			}
+		}
+
+		det, _ := Determinant(sub)
+		minors = append(minors, det)
+	}
+
+	return minors
+}
+
+//ClassifyForm returns the definiteness of the quadratic form of a symmetric matrix, counting the signs of the
+//diagonal entries of a congruent diagonal matrix (Sylvester's law of inertia).
+//An error is returned if m is not symmetric.
+func ClassifyForm(m M) (Definiteness, error) {
+	_, d, err := CongruenceDiagonalize(m)
+	if err != nil {
+		return ZeroForm, err
+	}
+
+	pos, neg := 0, 0
+	for k := 1; k <= d.Rows(); k++ {
+		switch n := d.Get(k, k).Numerator(); {
+		case n > 0:
+			pos++
+		case n < 0:
+			neg++
+		}
+	}
+
+	switch {
+	case pos > 0 && neg > 0:
+		return Indefinite, nil
+	case pos == d.Rows():
+		return PositiveDefinite, nil
+	case neg == d.Rows():
+		return NegativeDefinite, nil
+	case pos > 0:
+		return PositiveSemidefinite, nil
+	case neg > 0:
+		return NegativeSemidefinite, nil
+	}
+
+	return ZeroForm, nil
+}
+
+//ParseQuadraticForm parses a quadratic form written as a polynomial, such as "3x^2 + 4xy - y^2" or "x*z - 1/2 z^2",
+//and returns its symmetric matrix along with the variables in the order of its rows, which is alphabetical.
+//Every variable is a single letter and every term must have degree two.
+func ParseQuadraticForm(s string) (M, []rune, error) {
+	type term struct {
+		coeff Frac
+		vars  []rune
+	}
+
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+
+	if s == "" {
+		return M{}, nil, errors.New("the quadratic form is empty")
+	}
+
+	terms := []term{}
+	seen := map[rune]bool{}
+
+	i := 0
+	for i < len(s) {
+		t := term{coeff: NewScalarFrac(1)}
+
+		if s[i] == '+' || s[i] == '-' {
+			if s[i] == '-' {
+				t.coeff = t.coeff.Neg()
+			}
+			i++
+		} else if len(terms) > 0 {
+			return M{}, nil, fmt.Errorf("expected + or - at %q", s[i:])
+		}
+
+		start := i
+		for i < len(s) && (unicode.IsDigit(rune(s[i])) || s[i] == '/') {
+			i++
+		}
+
+		if i > start {
+			f, err := ParseFrac(s[start:i])
+			if err != nil {
+				return M{}, nil, fmt.Errorf("invalid coefficient %q", s[start:i])
+			}
+			t.coeff = t.coeff.Mul(f)
+		}
+
+		for i < len(s) && s[i] != '+' && s[i] != '-' {
+			switch {
+			case s[i] == '*':
+				i++
+			case unicode.IsLetter(rune(s[i])):
+				v := rune(s[i])
+				i++
+
+				power := 1
+				if i < len(s) && s[i] == '^' {
+					i++
+					start := i
+					for i < len(s) && unicode.IsDigit(rune(s[i])) {
+						i++
+					}
+
+					p, err := strconv.Atoi(s[start:i])
+					if err != nil {
+						return M{}, nil, fmt.Errorf("invalid exponent of %c", v)
+					}
+					power = p
+				}
+
+				for ; power > 0; power-- {
+					t.vars = append(t.vars, v)
+				}
+				seen[v] = true
+			default:
+				return M{}, nil, fmt.Errorf("unexpected %q", s[i])
+			}
+		}
+
+		if len(t.vars) != 2 {
+			return M{}, nil, fmt.Errorf("every term of a quadratic form must have degree two")
+		}
+
+		terms = append(terms, t)
+	}
+
+	vars := []rune{}
+	for v := range seen {
+		vars = append(vars, v)
+	}
+	sort.Slice(vars, func(a, b int) bool { return vars[a] < vars[b] })
+
+	index := map[rune]int{}
+	for k, v := range vars {
+		index[v] = k + 1
+	}
+
+	m := New(len(vars), len(vars))
+
+	for _, t := range terms {
+		r, c := index[t.vars[0]], index[t.vars[1]]
+
+		if r == c {
+			m.Set(r, c, m.Get(r, c).Add(t.coeff))
+			continue
+		}
+
+		half := t.coeff.Mul(NewFrac(1, 2)) // cross terms are split evenly across the two symmetric entries
+		m.Set(r, c, m.Get(r, c).Add(half))
+		m.Set(c, r, m.Get(c, r).Add(half))
+	}
+
+	return m, vars, nil
+}
@@ -0,0 +1,78 @@
+package matrix
+
+import "testing"
+
+func TestParseQuadraticForm(t *testing.T) {
+	m, vars, err := ParseQuadraticForm("3x^2 + 4xy - y^2")
+	if err != nil {
+		t.Fatalf("Got error parsing quadratic form: %v", err)
+	}
+
+	expected := manualMatrix([][]string{
+		{"3", "2"},
+		{"2", "-1"},
+	})
+
+	if !matrixEquals(m, expected) {
+		t.Errorf("Incorrect quadratic form matrix! Wanted\n %v but got\n %v", expected, m)
+	}
+
+	if string(vars) != "xy" {
+		t.Errorf("Quadratic form variables should be xy but were %s", string(vars))
+	}
+
+	for _, invalid := range []string{"", "x^3", "2x + y^2", "x^2 y^2", "x^2 ? y^2"} {
+		if _, _, err := ParseQuadraticForm(invalid); err == nil {
+			t.Errorf("Parsing %q must fail!", invalid)
+		}
+	}
+}
+
+func TestClassifyForm(t *testing.T) {
+	tests := map[Definiteness][][]string{
+		PositiveDefinite:     {{"2", "1"}, {"1", "2"}},
+		PositiveSemidefinite: {{"1", "1"}, {"1", "1"}},
+		NegativeDefinite:     {{"-2", "1"}, {"1", "-2"}},
+		Indefinite:           {{"0", "1"}, {"1", "0"}},
+		ZeroForm:             {{"0", "0"}, {"0", "0"}},
+	}
+
+	for expected, mat := range tests {
+		res, err := ClassifyForm(manualMatrix(mat))
+		if err != nil {
+			t.Fatalf("Got error classifying quadratic form: %v", err)
+		}
+
+		if res != expected {
+			t.Errorf("Quadratic form of %v should be %v but was %v", mat, expected, res)
+		}
+	}
+}
+
+func TestCongruenceDiagonalize(t *testing.T) {
+	input := manualMatrix([][]string{
+		{"0", "1", "0"},
+		{"1", "0", "2"},
+		{"0", "2", "0"},
+	})
+
+	p, d, err := CongruenceDiagonalize(input)
+	if err != nil {
+		t.Fatalf("Got error during congruence diagonalization: %v", err)
+	}
+
+	for r := 1; r <= 3; r++ {
+		for c := 1; c <= 3; c++ {
+			if r != c && !d.Get(r, c).IsZero() {
+				t.Fatalf("Congruent matrix must be diagonal but was\n %v", d)
+			}
+		}
+	}
+
+	product, _ := Multiply(Transpose(p), input)
+	product, _ = Multiply(product, p)
+
+	if !matrixEquals(product, d) {
+		t.Errorf("PᵀAP should be\n %v but was\n %v", d, product)
+	}
+}
@@ -0,0 +1,123 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+//Radical represents a number of the form a√b, where a is a fraction and b is a squarefree positive integer.
+type Radical struct {
+	coeff    Frac
+	radicand int
+}
+
+//NewRadical returns the number coeff√radicand, moving square factors of the radicand into the coefficient.
+//It panics if the radicand is not positive.
+func NewRadical(coeff Frac, radicand int) Radical {
+	if radicand <= 0 {
+		panic("Radicand must be positive!")
+	}
+
+	if coeff.IsZero() {
+		return Radical{coeff: NewScalarFrac(0), radicand: 1}
+	}
+
+	for i := 2; i*i <= radicand; i++ {
+		for radicand%(i*i) == 0 {
+			radicand /= i * i
+			coeff = coeff.Mul(NewScalarFrac(i))
+		}
+	}
+
+	return Radical{coeff: coeff.Reduce(), radicand: radicand}
+}
+
+//SqrtFrac returns the non-negative square root of a fraction.
+//An error is returned if the fraction is negative.
+func SqrtFrac(f Frac) (Radical, error) {
+	f = f.Reduce()
+
+	if f.Numerator() < 0 {
+		return Radical{}, fmt.Errorf("%v has no real square root", f)
+	}
+
+	if f.IsZero() {
+		return NewRadical(f, 1), nil
+	}
+
+	// √(n/d) = √(nd)/d
+	return NewRadical(NewFrac(1, f.Denominator()), f.Numerator()*f.Denominator()), nil
+}
+
+//Coeff returns the rational coefficient of the radical.
+func (r Radical) Coeff() Frac {
+	return r.coeff
+}
+
+//Radicand returns the squarefree number under the root.
+func (r Radical) Radicand() int {
+	return r.radicand
+}
+
+//IsRational returns true if the radical has no root left in it.
+func (r Radical) IsRational() bool {
+	return r.radicand == 1
+}
+
+//Scale multiplies the radical by a fraction.
+func (r Radical) Scale(f Frac) Radical {
+	return NewRadical(r.coeff.Mul(f), r.radicand)
+}
+
+//Float returns the floating-point value closest to the radical.
+func (r Radical) Float() float64 {
+	return r.coeff.Float() * math.Sqrt(float64(r.radicand))
+}
+
+//String returns a string representation of the radical, such as "2√3" or "(1/2)√6".
+func (r Radical) String() string {
+	if r.IsRational() {
+		return r.coeff.String()
+	}
+
+	root := fmt.Sprintf("√%d", r.radicand)
+
+	switch {
+	case r.coeff.Equals(NewScalarFrac(1)):
+		return root
+	case r.coeff.Equals(NewScalarFrac(-1)):
+		return "-" + root
+	case r.coeff.IsWhole():
+		return r.coeff.String() + root
+	}
+
+	return "(" + r.coeff.String() + ")" + root
+}
+
+//RadM represents a matrix with radical entries.
+type RadM struct {
+	grid[Radical]
+}
+
+//NewRadM returns a zero radical matrix of size r,c.
+func NewRadM(r, c int) RadM {
+	return RadM{newGrid(r, c, NewRadical(NewScalarFrac(0), 1))}
+}
+
+//Float returns a floating-point approximation of the matrix.
+func (m RadM) Float() FM {
+	fm := NewFM(m.r, m.c)
+
+	for i, v := range m.values {
+		fm.values[i] = v.Float()
+	}
+
+	return fm
+}
+
+//ScaleRadM multiplies every entry of the matrix by s.
+func ScaleRadM(s Frac, m RadM) RadM {
+	return RadM{mapGrid(m.grid, func(v Radical) Radical {
+		return v.Scale(s)
+	})}
+}
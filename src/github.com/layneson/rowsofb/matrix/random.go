@@ -0,0 +1,133 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+//RandomInt returns a matrix of size r,c with integer entries drawn uniformly from lo through hi, inclusive.
+func RandomInt(rng *rand.Rand, r, c, lo, hi int) M {
+	rm := New(r, c)
+
+	for i := 1; i <= r; i++ {
+		for j := 1; j <= c; j++ {
+			rm.Set(i, j, NewScalarFrac(lo+rng.Intn(hi-lo+1)))
+		}
+	}
+
+	return rm
+}
+
+//randomNonzero returns a random integer from -2 through 2 other than zero.
+func randomNonzero(rng *rand.Rand) int {
+	s := 1 + rng.Intn(2)
+	if rng.Intn(2) == 0 {
+		s = -s
+	}
+
+	return s
+}
+
+//RandomUnimodular returns a random integer matrix of size n with determinant 1 or -1, so that its inverse has integer entries too.
+//It is the product of a few elementary matrices which switch rows or add small integer multiples of one row to another.
+func RandomUnimodular(rng *rand.Rand, n int) M {
+	rm := Identity(n)
+	if n < 2 {
+		return rm
+	}
+
+	for k := 0; k < 2*n; k++ {
+		r1 := 1 + rng.Intn(n)
+		r2 := 1 + rng.Intn(n-1)
+		if r2 >= r1 {
+			r2++
+		}
+
+		if rng.Intn(4) == 0 {
+			rm.Apply(RowOp[Frac]{Kind: SwitchOp, R1: r1, R2: r2})
+		} else {
+			rm.Apply(RowOp[Frac]{Kind: AddOp, R1: r1, R2: r2, Scalar: NewScalarFrac(randomNonzero(rng))})
+		}
+	}
+
+	return rm
+}
+
+//RandomWithDeterminant returns a random integer matrix of size n whose determinant is det.
+func RandomWithDeterminant(rng *rand.Rand, n, det int) (M, error) {
+	if n < 1 {
+		return New(0, 0), errors.New("a matrix with a determinant needs at least one row")
+	}
+
+	//An upper triangular matrix with determinant det, hidden by unimodular row and column operations.
+	t := RandomInt(rng, n, n, -3, 3)
+	for r := 1; r <= n; r++ {
+		for c := 1; c < r; c++ {
+			t.Set(r, c, NewScalarFrac(0))
+		}
+		t.Set(r, r, NewScalarFrac(1))
+	}
+	t.Set(n, n, NewScalarFrac(det))
+
+	u, v := RandomUnimodular(rng, n), RandomUnimodular(rng, n)
+
+	rm, _ := Multiply(u, t)
+	rm, _ = Multiply(rm, v)
+
+	//The row switches may have flipped the sign, which negating a row undoes.
+	if d, _ := Determinant(rm); !d.Equals(NewScalarFrac(det)) {
+		rm.MultiplyRow(1+rng.Intn(n), NewScalarFrac(-1))
+	}
+
+	return rm, nil
+}
+
+//RandomWithRank returns a random integer matrix of size r,c with rank k.
+func RandomWithRank(rng *rand.Rand, r, c, k int) (M, error) {
+	if k < 0 || k > r || k > c {
+		return New(0, 0), fmt.Errorf("a %dx%d matrix cannot have rank %d", r, c, k)
+	}
+
+	d := New(r, c)
+	for i := 1; i <= k; i++ {
+		d.Set(i, i, NewScalarFrac(1))
+	}
+
+	rm, _ := Multiply(RandomUnimodular(rng, r), d)
+	rm, _ = Multiply(rm, RandomUnimodular(rng, c))
+
+	return rm, nil
+}
+
+//RandomIntegerRref returns a random integer matrix of size r,c with rank k whose reduced row echelon form also has integer entries.
+func RandomIntegerRref(rng *rand.Rand, r, c, k int) (M, error) {
+	if k < 0 || k > r || k > c {
+		return New(0, 0), fmt.Errorf("a %dx%d matrix cannot have rank %d", r, c, k)
+	}
+
+	pivots := rng.Perm(c)[:k]
+	sort.Ints(pivots)
+
+	isPivot := make([]bool, c+1)
+	for _, p := range pivots {
+		isPivot[p+1] = true
+	}
+
+	//Build the reduced row echelon form first, then hide it with unimodular row operations.
+	rref := New(r, c)
+	for i, p := range pivots {
+		rref.Set(i+1, p+1, NewScalarFrac(1))
+
+		for col := p + 2; col <= c; col++ {
+			if !isPivot[col] {
+				rref.Set(i+1, col, NewScalarFrac(rng.Intn(7)-3))
+			}
+		}
+	}
+
+	rm, _ := Multiply(RandomUnimodular(rng, r), rref)
+
+	return rm, nil
+}
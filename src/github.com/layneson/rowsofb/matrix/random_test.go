@@ -0,0 +1,91 @@
+package matrix
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func isIntegerMatrix(m M) bool {
+	for r := 1; r <= m.Rows(); r++ {
+		for c := 1; c <= m.Cols(); c++ {
+			if !m.Get(r, c).IsWhole() {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func rank(m M) int {
+	m = Rref(m)
+
+	k := 0
+	for r := 1; r <= m.Rows(); r++ {
+		for c := 1; c <= m.Cols(); c++ {
+			if !m.Get(r, c).IsZero() {
+				k++
+				break
+			}
+		}
+	}
+
+	return k
+}
+
+func TestRandomInt(t *testing.T) {
+	a := RandomInt(rand.New(rand.NewSource(7)), 3, 4, -5, 5)
+	b := RandomInt(rand.New(rand.NewSource(7)), 3, 4, -5, 5)
+
+	if !matrixEquals(a, b) {
+		t.Errorf("The same seed must give the same matrix! Got\n %v and\n %v", a, b)
+	}
+
+	for r := 1; r <= a.Rows(); r++ {
+		for c := 1; c <= a.Cols(); c++ {
+			if v := a.Get(r, c); !v.IsWhole() || v.Integer() < -5 || v.Integer() > 5 {
+				t.Errorf("Entry %v is outside of the range -5 through 5", v)
+			}
+		}
+	}
+}
+
+func TestRandomWithDeterminant(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, det := range []int{1, -1, 6, 0} {
+		m, err := RandomWithDeterminant(rng, 4, det)
+		if err != nil {
+			t.Fatalf("Got error during random matrix generation: %v", err)
+		}
+
+		if d, _ := Determinant(m); !isIntegerMatrix(m) || !d.Equals(NewScalarFrac(det)) {
+			t.Errorf("Wanted an integer matrix with determinant %d but got\n %v with determinant %v", det, m, d)
+		}
+	}
+
+	inv, err := Inverse(RandomUnimodular(rng, 5))
+	if err != nil || !isIntegerMatrix(inv) {
+		t.Errorf("Unimodular matrices must have an integer inverse, but got\n %v (error %v)", inv, err)
+	}
+}
+
+func TestRandomWithRank(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for k := 0; k <= 3; k++ {
+		m, _ := RandomWithRank(rng, 3, 5, k)
+		if !isIntegerMatrix(m) || rank(m) != k {
+			t.Errorf("Wanted an integer matrix with rank %d but got\n %v", k, m)
+		}
+
+		m, _ = RandomIntegerRref(rng, 4, 5, k)
+		if !isIntegerMatrix(m) || !isIntegerMatrix(Rref(m)) || rank(m) != k {
+			t.Errorf("Wanted an integer matrix with an integer rref of rank %d but got\n %v", k, m)
+		}
+	}
+
+	if _, err := RandomWithRank(rng, 2, 3, 3); err == nil {
+		t.Error("A 2x3 matrix must not have rank 3!")
+	}
+}
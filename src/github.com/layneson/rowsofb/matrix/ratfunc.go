@@ -0,0 +1,345 @@
+package matrix
+
+import (
+	"fmt"
+	"strings"
+)
+
+//RatFunc represents a rational function num/den in a single named parameter.
+type RatFunc struct {
+	//Numerator and denominator. They share no common factor and den is monic.
+	num, den Poly
+
+	//Name of the parameter, or zero if the function was built from constants only.
+	v rune
+}
+
+//NewRatFunc returns the rational function num/den in the parameter v.
+//It panics if den is the zero polynomial.
+func NewRatFunc(num, den Poly, v rune) RatFunc {
+	if den.IsZero() {
+		panic("Zero denominator not acceptable!")
+	}
+
+	g := PolyGCD(num, den)
+	num, _ = num.DivMod(g)
+	den, _ = den.DivMod(g)
+
+	lead := den.Lead()
+
+	return RatFunc{num: num.Scale(lead.Reciprocal()), den: den.Monic(), v: v}
+}
+
+//NewParam returns the rational function consisting of the parameter v alone.
+func NewParam(v rune) RatFunc {
+	return RatFunc{num: PolyX(), den: NewScalarPoly(NewScalarFrac(1)), v: v}
+}
+
+//RatFuncFromFrac returns the constant rational function f.
+func RatFuncFromFrac(f Frac) RatFunc {
+	return RatFunc{num: NewScalarPoly(f), den: NewScalarPoly(NewScalarFrac(1))}
+}
+
+//Num returns the numerator of the rational function.
+func (f RatFunc) Num() Poly {
+	return f.num
+}
+
+//Den returns the monic denominator of the rational function.
+func (f RatFunc) Den() Poly {
+	return f.den
+}
+
+//Param returns the name of the rational function's parameter, or zero if it has none.
+func (f RatFunc) Param() rune {
+	return f.v
+}
+
+//param returns the parameter shared by two rational functions.
+//It panics if they are in different parameters.
+func param(f1, f2 RatFunc) rune {
+	if f1.v == 0 {
+		return f2.v
+	}
+
+	if f2.v != 0 && f2.v != f1.v {
+		panic("Rational functions in different parameters cannot be combined!")
+	}
+
+	return f1.v
+}
+
+//IsZero returns true if the rational function is zero.
+func (f RatFunc) IsZero() bool {
+	return f.num.IsZero()
+}
+
+//IsConstant returns true if the rational function does not depend on its parameter.
+func (f RatFunc) IsConstant() bool {
+	return f.num.IsConstant() && f.den.IsConstant()
+}
+
+//Constant returns the value of a constant rational function.
+func (f RatFunc) Constant() Frac {
+	return f.num.Coeff(0)
+}
+
+//Equals returns true if the two rational functions are equivalent, and false otherwise.
+func (f RatFunc) Equals(f1 RatFunc) bool {
+	return f.num.Equals(f1.num) && f.den.Equals(f1.den)
+}
+
+//Add adds two rational functions and returns the result.
+func (f1 RatFunc) Add(f2 RatFunc) RatFunc {
+	return NewRatFunc(f1.num.Mul(f2.den).Add(f2.num.Mul(f1.den)), f1.den.Mul(f2.den), param(f1, f2))
+}
+
+//Neg negates the rational function.
+func (f RatFunc) Neg() RatFunc {
+	return RatFunc{num: f.num.Neg(), den: f.den, v: f.v}
+}
+
+//Mul multiplies two rational functions and returns the result.
+func (f1 RatFunc) Mul(f2 RatFunc) RatFunc {
+	return NewRatFunc(f1.num.Mul(f2.num), f1.den.Mul(f2.den), param(f1, f2))
+}
+
+//Reciprocal returns the reciprocal of the rational function.
+//It panics if the function is zero.
+func (f RatFunc) Reciprocal() RatFunc {
+	return NewRatFunc(f.den, f.num, f.v)
+}
+
+//Inv returns the reciprocal of the rational function. It panics if the function is zero.
+func (f RatFunc) Inv() RatFunc {
+	return f.Reciprocal()
+}
+
+//Zero returns the zero rational function in the same parameter.
+func (f RatFunc) Zero() RatFunc {
+	return RatFunc{num: Poly{}, den: NewScalarPoly(NewScalarFrac(1)), v: f.v}
+}
+
+//One returns the constant rational function one in the same parameter.
+func (f RatFunc) One() RatFunc {
+	return RatFunc{num: NewScalarPoly(NewScalarFrac(1)), den: NewScalarPoly(NewScalarFrac(1)), v: f.v}
+}
+
+//Div divides the rational function by another and returns the result.
+//It panics if f2 is zero.
+func (f1 RatFunc) Div(f2 RatFunc) RatFunc {
+	return f1.Mul(f2.Reciprocal())
+}
+
+//Eval evaluates the rational function with its parameter set to p.
+//An error is returned if the denominator vanishes at p.
+func (f RatFunc) Eval(p Frac) (Frac, error) {
+	d := f.den.Eval(p)
+	if d.IsZero() {
+		return Frac{}, fmt.Errorf("%v is undefined when %c = %v", f, f.v, p)
+	}
+
+	return f.num.Eval(p).Div(d).Reduce(), nil
+}
+
+//String returns a string representation of the rational function, such as "(k + 1)/(k - 2)".
+func (f RatFunc) String() string {
+	v := f.v
+	if v == 0 {
+		v = 'x'
+	}
+
+	num := f.num.StringIn(v)
+	if f.den.IsConstant() {
+		return num
+	}
+
+	if strings.Contains(num, " ") {
+		num = "(" + num + ")"
+	}
+
+	den := f.den.StringIn(v)
+	if strings.Contains(den, " ") || strings.Contains(den, "^") {
+		den = "(" + den + ")"
+	}
+
+	return num + "/" + den
+}
+
+//A Split is a special case of symbolic row reduction. It describes parameter values at which a pivot vanishes,
+//so that the generic result does not hold there.
+type Split struct {
+	//Param is the name of the parameter.
+	Param rune
+
+	//Factor is a monic factor of a pivot whose roots are the special parameter values.
+	Factor Poly
+
+	//Value is the root of Factor, if Factor is linear.
+	Value Frac
+
+	//Rational is true if Factor is linear and Value holds its root.
+	Rational bool
+}
+
+//String returns the condition under which the split applies, such as "k = 2" or "k^2 + 1 = 0".
+func (s Split) String() string {
+	if s.Rational {
+		return fmt.Sprintf("%c = %v", s.Param, s.Value)
+	}
+
+	return fmt.Sprintf("%s = 0", s.Factor.StringIn(s.Param))
+}
+
+//splitsOf returns the splits at which any of the given polynomials vanish.
+func splitsOf(v rune, polys []Poly) []Split {
+	splits := []Split{}
+
+	add := func(s Split) {
+		for _, ss := range splits {
+			if ss.Factor.Equals(s.Factor) {
+				return
+			}
+		}
+
+		splits = append(splits, s)
+	}
+
+	for _, p := range polys {
+		if p.IsConstant() {
+			continue
+		}
+
+		for _, root := range p.RationalRoots() {
+			factor := NewPoly(root.Neg(), NewScalarFrac(1))
+			for {
+				q, r := p.DivMod(factor)
+				if !r.IsZero() {
+					break
+				}
+				p = q
+			}
+
+			add(Split{Param: v, Factor: factor, Value: root, Rational: true})
+		}
+
+		if !p.IsConstant() {
+			add(Split{Param: v, Factor: p.Monic()})
+		}
+	}
+
+	return splits
+}
+
+//RM represents a matrix whose entries are rational functions in a parameter.
+type RM = Mat[RatFunc]
+
+//NewRM returns a zero matrix of rational functions of size r,c.
+func NewRM(r, c int) RM {
+	return NewMat(r, c, RatFuncFromFrac(NewScalarFrac(0)))
+}
+
+//RMFromMatrix returns a matrix of rational functions with the constant entries of m.
+func RMFromMatrix(m M) RM {
+	rm := NewRM(m.Rows(), m.Cols())
+
+	for r := 1; r <= m.Rows(); r++ {
+		for c := 1; c <= m.Cols(); c++ {
+			rm.Set(r, c, RatFuncFromFrac(m.Get(r, c)))
+		}
+	}
+
+	return rm
+}
+
+//ParamRM returns the name of the parameter the matrix depends on, or zero if every entry is constant.
+func ParamRM(m RM) rune {
+	for _, f := range m.values {
+		if f.v != 0 {
+			return f.v
+		}
+	}
+
+	return 0
+}
+
+//EvalRM returns the matrix of fractions obtained by setting the parameter to p.
+//An error is returned if an entry is undefined at p.
+func EvalRM(m RM, p Frac) (M, error) {
+	rm := New(m.r, m.c)
+
+	for r := 1; r <= m.Rows(); r++ {
+		for c := 1; c <= m.Cols(); c++ {
+			f, err := m.Get(r, c).Eval(p)
+			if err != nil {
+				return rm, err
+			}
+
+			rm.Set(r, c, f)
+		}
+	}
+
+	return rm, nil
+}
+
+//CopyRM creates a copy of the matrix, with same contents and size.
+func CopyRM(m RM) RM {
+	return CopyMat(m)
+}
+
+//AddRM adds matrix a to matrix b.
+//It returns an error if a and b are not the same size.
+func AddRM(a, b RM) (RM, error) {
+	return AddMat(a, b)
+}
+
+//ScaleRM multiplies every entry of the matrix by s.
+func ScaleRM(s RatFunc, m RM) RM {
+	return ScaleMat(s, m)
+}
+
+//MultiplyRM multiplies a by b. If the matrices cannot be multiplied, an error is returned.
+func MultiplyRM(a, b RM) (RM, error) {
+	return MultiplyMat(a, b)
+}
+
+//AugmentRM augments a with b then returns this matrix.
+//It returns an error if the two matrices do not have the same number of rows.
+func AugmentRM(a, b RM) (RM, error) {
+	return AugmentMat(a, b)
+}
+
+//RrefRM takes a copy of a matrix of rational functions and returns its generic reduced row echelon form,
+//which holds for every parameter value except those described by the returned splits.
+//Each pivot is assumed to be nonzero, so each split is a parameter value at which some pivot vanishes.
+//The pivots are read from the row operations of the reduction: each one is scaled to one by its reciprocal.
+func RrefRM(m RM) (RM, []Split) {
+	m, ops := RrefOps(m)
+
+	pivots := []Poly{}
+	for _, op := range ops {
+		if op.Kind == ScaleOp {
+			pivots = append(pivots, op.Scalar.den)
+		}
+	}
+
+	return m, splitsOf(ParamRM(m), pivots)
+}
+
+//DeterminantRM returns the determinant of a matrix of rational functions.
+//An error is returned if the matrix is not square.
+func DeterminantRM(m RM) (RatFunc, error) {
+	return DeterminantMat(m)
+}
+
+//ZerosRM returns the splits at which a rational function vanishes.
+func ZerosRM(f RatFunc) []Split {
+	return splitsOf(f.v, []Poly{f.num})
+}
+
+//InverseRM takes a copy of a matrix of rational functions and returns its generic inverse.
+//The inverse does not exist at the zeros of the determinant, which can be found with ZerosRM.
+//An error is returned if the matrix is not invertible for any parameter value.
+func InverseRM(m RM) (RM, error) {
+	return InverseMat(m)
+}
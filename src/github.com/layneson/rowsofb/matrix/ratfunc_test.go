@@ -0,0 +1,149 @@
+package matrix
+
+import "testing"
+
+func paramMatrix(v rune, mat [][]string) RM {
+	m := NewRM(len(mat), len(mat[0]))
+
+	for r := 0; r < len(mat); r++ {
+		for c := 0; c < len(mat[r]); c++ {
+			if mat[r][c] == string(v) {
+				m.Set(r+1, c+1, NewParam(v))
+				continue
+			}
+
+			f, _ := ParseFrac(mat[r][c])
+			m.Set(r+1, c+1, RatFuncFromFrac(f))
+		}
+	}
+
+	return m
+}
+
+func TestRationalRoots(t *testing.T) {
+	tests := []struct {
+		p     Poly
+		roots []Frac
+	}{
+		{manualPoly(1, -3, 2), []Frac{NewFrac(1, 2), NewScalarFrac(1)}},
+		{manualPoly(0, -1, 0, 1), []Frac{NewScalarFrac(-1), NewScalarFrac(0), NewScalarFrac(1)}},
+		{manualPoly(1, 0, 1), []Frac{}},
+		{manualPoly(4), []Frac{}},
+	}
+
+	for _, tst := range tests {
+		roots := tst.p.RationalRoots()
+		if len(roots) != len(tst.roots) {
+			t.Errorf("%v should have roots %v but had %v", tst.p, tst.roots, roots)
+			continue
+		}
+
+		for i := range roots {
+			if !roots[i].Equals(tst.roots[i]) {
+				t.Errorf("%v should have roots %v but had %v", tst.p, tst.roots, roots)
+				break
+			}
+		}
+	}
+}
+
+func TestRatFunc(t *testing.T) {
+	k := NewParam('k')
+	one := RatFuncFromFrac(NewScalarFrac(1))
+	two := RatFuncFromFrac(NewScalarFrac(2))
+
+	f := k.Add(one).Div(k.Add(two.Neg()))
+	if f.String() != "(k + 1)/(k - 2)" {
+		t.Errorf("Rational function should print as %q but printed as %q", "(k + 1)/(k - 2)", f.String())
+	}
+
+	g := f.Mul(k.Add(two.Neg()))
+	if !g.Equals(k.Add(one)) {
+		t.Errorf("Rational function should cancel to k + 1 but was %v", g)
+	}
+
+	if _, err := f.Eval(NewScalarFrac(2)); err == nil {
+		t.Error("Rational function must be undefined at its pole!")
+	}
+}
+
+func TestRrefRM(t *testing.T) {
+	input := paramMatrix('k', [][]string{
+		{"1", "1", "1"},
+		{"1", "k", "2"},
+	})
+
+	res, splits := RrefRM(input)
+
+	k := NewParam('k')
+	one := RatFuncFromFrac(NewScalarFrac(1))
+
+	if !res.Get(1, 1).Equals(one) || !res.Get(2, 2).Equals(one) || !res.Get(1, 2).IsZero() {
+		t.Errorf("Incorrect symbolic row reduction result! Got\n %v", res)
+	}
+
+	// x2 = 1/(k - 1), x1 = 1 - 1/(k - 1) = (k - 2)/(k - 1)
+	if !res.Get(2, 3).Equals(one.Div(k.Add(one.Neg()))) {
+		t.Errorf("Incorrect symbolic row reduction result! Got\n %v", res)
+	}
+
+	if len(splits) != 1 || !splits[0].Rational || !splits[0].Value.Equals(NewScalarFrac(1)) {
+		t.Fatalf("Row reduction should split at k = 1 but split at %v", splits)
+	}
+
+	special, err := EvalRM(input, splits[0].Value)
+	if err != nil {
+		t.Fatalf("Got error evaluating matrix: %v", err)
+	}
+
+	expected := manualMatrix([][]string{
+		{"1", "1", "0"},
+		{"0", "0", "1"},
+	})
+
+	if !matrixEquals(Rref(special), expected) {
+		t.Errorf("Incorrect special case! Wanted\n %v but got\n %v", expected, Rref(special))
+	}
+}
+
+func TestDeterminantRM(t *testing.T) {
+	input := paramMatrix('k', [][]string{
+		{"k", "1", "1"},
+		{"1", "k", "1"},
+		{"1", "1", "k"},
+	})
+
+	det, err := DeterminantRM(input)
+	if err != nil {
+		t.Fatalf("Got error during symbolic determinant calculation: %v", err)
+	}
+
+	// (k - 1)^2 (k + 2)
+	expected := RatFunc{num: manualPoly(2, -3, 0, 1), den: manualPoly(1), v: 'k'}
+	if !det.Equals(expected) {
+		t.Errorf("Determinant should be %v but was %v", expected, det)
+	}
+
+	splits := ZerosRM(det)
+	if len(splits) != 2 || !splits[0].Value.Equals(NewScalarFrac(-2)) || !splits[1].Value.Equals(NewScalarFrac(1)) {
+		t.Errorf("Determinant should vanish at k = -2 and k = 1 but vanished at %v", splits)
+	}
+}
+
+func TestInverseRM(t *testing.T) {
+	input := paramMatrix('k', [][]string{
+		{"1", "1"},
+		{"1", "k"},
+	})
+
+	inv, err := InverseRM(input)
+	if err != nil {
+		t.Fatalf("Got error during symbolic inverse calculation: %v", err)
+	}
+
+	product, _ := MultiplyRM(input, inv)
+	if !product.Get(1, 1).Equals(RatFuncFromFrac(NewScalarFrac(1))) || !product.Get(1, 2).IsZero() ||
+		!product.Get(2, 1).IsZero() || !product.Get(2, 2).Equals(RatFuncFromFrac(NewScalarFrac(1))) {
+		t.Errorf("Incorrect symbolic inverse! Got\n %v", inv)
+	}
+}
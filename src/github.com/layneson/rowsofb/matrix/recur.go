@@ -0,0 +1,244 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//Recurrence is a linear recurrence of order k, a_{n+k} = c_1 a_{n+k-1} + c_2 a_{n+k-2} + ... + c_k a_n,
+//with its first k terms a_0 through a_{k-1}.
+type Recurrence struct {
+	Coeffs  []Frac //c_1 through c_k, most recent term first
+	Initial []Frac //a_0 through a_{k-1}
+}
+
+//NewRecurrence returns the recurrence with the given coefficients, most recent term first, and initial values.
+//An error is returned if the numbers of coefficients and initial values differ, or if the last coefficient is zero,
+//in which case the recurrence has a lower order.
+func NewRecurrence(coeffs, initial []Frac) (Recurrence, error) {
+	if len(coeffs) == 0 {
+		return Recurrence{}, errors.New("a recurrence needs at least one coefficient")
+	}
+
+	if len(coeffs) != len(initial) {
+		return Recurrence{}, fmt.Errorf("a recurrence with %d coefficients needs %d initial values, not %d", len(coeffs), len(coeffs), len(initial))
+	}
+
+	if coeffs[len(coeffs)-1].IsZero() {
+		return Recurrence{}, errors.New("the last coefficient must be nonzero; drop it to lower the order of the recurrence")
+	}
+
+	return Recurrence{Coeffs: coeffs, Initial: initial}, nil
+}
+
+//CharPoly returns the characteristic polynomial x^k - c_1 x^{k-1} - ... - c_k of the recurrence.
+func (rec Recurrence) CharPoly() Poly {
+	k := len(rec.Coeffs)
+
+	coeffs := make([]Frac, k+1)
+	coeffs[k] = NewScalarFrac(1)
+	for i, c := range rec.Coeffs {
+		coeffs[k-1-i] = c.Neg()
+	}
+
+	return NewPoly(coeffs...)
+}
+
+//Matrix returns the matrix which moves the window of terms (a_n, ..., a_{n+k-1}) one step forward.
+//It is the transpose of the companion matrix of the characteristic polynomial.
+func (rec Recurrence) Matrix() M {
+	return Transpose(Companion(rec.CharPoly()))
+}
+
+//Term returns a_n together with the nth power of the matrix of the recurrence, which is found with repeated squaring.
+//An error is returned if n is negative, or if a_n or the power is too large to be represented exactly.
+func (rec Recurrence) Term(n int) (Frac, M, error) {
+	if n < 0 {
+		return Frac{}, M{}, fmt.Errorf("term %d does not exist; terms are numbered from 0", n)
+	}
+
+	p, err := PowerChecked(rec.Matrix(), n)
+	if err != nil {
+		return Frac{}, M{}, fmt.Errorf("a_%d cannot be computed: %v", n, err)
+	}
+
+	a := New(len(rec.Initial), 1)
+	for i, f := range rec.Initial {
+		a.Set(i+1, 1, f)
+	}
+
+	s, ok := multiplyChecked(p, a)
+	if !ok {
+		return Frac{}, M{}, fmt.Errorf("a_%d cannot be computed: %v", n, errOverflow)
+	}
+
+	return s.Get(1, 1), p, nil
+}
+
+//ClosedForm returns a formula for a_n, found by writing the initial values in terms of the eigenvalues of the matrix
+//of the recurrence, which are the roots of its characteristic polynomial. A root r of multiplicity m contributes the terms
+//r^n, n·r^n, ..., n^{m-1}·r^n. An error is returned unless every root is rational, apart from at most one pair of
+//real conjugate quadratic surds u ± v√d.
+func (rec Recurrence) ClosedForm() (string, error) {
+	k := len(rec.Coeffs)
+
+	roots, mults, rest := rec.CharPoly().SplitRationalRoots()
+
+	//What is left is monic with no rational roots: nothing, or an irreducible quadratic x² + bx + c.
+	var u Frac
+	var v Radical
+	surd := false
+
+	switch rest.Degree() {
+	case 0:
+	case 2:
+		b, c := rest.Coeff(1), rest.Coeff(0)
+		disc := b.Mul(b).Add(NewScalarFrac(-4).Mul(c)).Reduce()
+		if disc.Numerator() < 0 {
+			return "", fmt.Errorf("the characteristic polynomial has the complex roots of %v", rest)
+		}
+
+		u = b.Mul(NewFrac(-1, 2)).Reduce()
+		v, _ = SqrtFrac(disc)
+		v = v.Scale(NewFrac(1, 2))
+		surd = true
+	default:
+		return "", fmt.Errorf("the characteristic polynomial has the factor %v, whose roots are not rational or quadratic surds", rest)
+	}
+
+	//Each unknown coefficient gets a column holding its term for n = 0, ..., k-1.
+	sys := New(k, k)
+	col := 1
+	for i, r := range roots {
+		for j := 0; j < mults[i]; j++ {
+			pow := NewScalarFrac(1)
+			for n := 0; n < k; n++ {
+				sys.Set(n+1, col, NewScalarFrac(intPow(n, j)).Mul(pow))
+				pow = pow.Mul(r).Reduce()
+			}
+			col++
+		}
+	}
+
+	//The conjugate pair contributes (α + β√d)λ^n + (α - β√d)λ̄^n. Writing λ^n = P_n + Q_n√d, this is 2αP_n + 2dβQ_n.
+	d := v.Radicand()
+	if surd {
+		p, q := NewScalarFrac(1), NewScalarFrac(0)
+		for n := 0; n < k; n++ {
+			sys.Set(n+1, col, p.Mul(NewScalarFrac(2)))
+			sys.Set(n+1, col+1, q.Mul(NewScalarFrac(2*d)))
+			p, q = p.Mul(u).Add(q.Mul(v.Coeff()).Mul(NewScalarFrac(d))).Reduce(), p.Mul(v.Coeff()).Add(q.Mul(u)).Reduce()
+		}
+	}
+
+	a := New(k, 1)
+	for i, f := range rec.Initial {
+		a.Set(i+1, 1, f)
+	}
+
+	inv, err := Inverse(sys)
+	if err != nil {
+		return "", errors.New("the initial values cannot be matched to the roots")
+	}
+
+	x, _ := Multiply(inv, a)
+
+	terms := []string{}
+	col = 1
+	for i, r := range roots {
+		for j := 0; j < mults[i]; j++ {
+			if c := x.Get(col, 1).Reduce(); !c.IsZero() {
+				terms = append(terms, rationalTerm(c, j, r))
+			}
+			col++
+		}
+	}
+
+	if surd {
+		alpha, beta := x.Get(col, 1).Reduce(), x.Get(col+1, 1).Reduce()
+		if !alpha.IsZero() || !beta.IsZero() {
+			for _, sign := range []Frac{NewScalarFrac(1), NewScalarFrac(-1)} {
+				terms = append(terms, fmt.Sprintf("(%s)·(%s)^n", SurdString(alpha, NewRadical(beta.Mul(sign), d)), SurdString(u, v.Scale(sign))))
+			}
+		}
+	}
+
+	if len(terms) == 0 {
+		return "a_n = 0", nil
+	}
+
+	s := "a_n = " + terms[0]
+	for _, t := range terms[1:] {
+		if strings.HasPrefix(t, "-") {
+			s += " - " + t[1:]
+		} else {
+			s += " + " + t
+		}
+	}
+
+	return s, nil
+}
+
+//rationalTerm writes the term c·n^j·r^n, leaving out factors which are one.
+func rationalTerm(c Frac, j int, r Frac) string {
+	parts := []string{}
+
+	switch j {
+	case 0:
+	case 1:
+		parts = append(parts, "n")
+	default:
+		parts = append(parts, "n^"+strconv.Itoa(j))
+	}
+
+	if !r.Equals(NewScalarFrac(1)) {
+		if r.IsWhole() && r.Numerator() > 0 {
+			parts = append(parts, r.String()+"^n")
+		} else {
+			parts = append(parts, "("+r.String()+")^n")
+		}
+	}
+
+	body := strings.Join(parts, "·")
+
+	switch {
+	case body == "":
+		return c.String()
+	case c.Equals(NewScalarFrac(1)):
+		return body
+	case c.Equals(NewScalarFrac(-1)):
+		return "-" + body
+	case c.IsWhole():
+		return c.String() + "·" + body
+	case c.Numerator() < 0:
+		return "-(" + c.Neg().String() + ")·" + body
+	}
+
+	return "(" + c.String() + ")·" + body
+}
+
+//SurdString writes the number u + r, such as "1/2 - (1/2)√5".
+func SurdString(u Frac, r Radical) string {
+	switch {
+	case r.Coeff().IsZero():
+		return u.String()
+	case u.IsZero():
+		return r.String()
+	case r.Coeff().Numerator() < 0:
+		return u.String() + " - " + r.Scale(NewScalarFrac(-1)).String()
+	}
+
+	return u.String() + " + " + r.String()
+}
+
+//intPow returns b to the power e, with 0^0 = 1.
+func intPow(b, e int) int {
+	p := 1
+	for i := 0; i < e; i++ {
+		p *= b
+	}
+
+	return p
+}
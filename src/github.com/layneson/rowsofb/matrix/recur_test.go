@@ -0,0 +1,78 @@
+package matrix
+
+import "testing"
+
+func TestRecurrenceTerm(t *testing.T) {
+	rec, err := NewRecurrence(fracs(1, 1), fracs(0, 1))
+	if err != nil {
+		t.Fatalf("Got error while building the Fibonacci recurrence: %v", err)
+	}
+
+	tests := map[int]int{0: 0, 1: 1, 2: 1, 10: 55, 50: 12586269025}
+	for n, expected := range tests {
+		a, _, err := rec.Term(n)
+		if err != nil {
+			t.Fatalf("Got error while finding term %d: %v", n, err)
+		}
+
+		if !fractionEquals(a, NewScalarFrac(expected)) {
+			t.Errorf("Term %d of the Fibonacci sequence should be %d but was %v", n, expected, a)
+		}
+	}
+
+	for _, n := range []int{93, 100} {
+		if _, _, err := rec.Term(n); err == nil {
+			t.Errorf("Term %d of the Fibonacci sequence overflows and should be rejected", n)
+		}
+	}
+
+	a, p, err := rec.Term(91)
+	if err != nil {
+		t.Fatalf("Got error while finding term 91: %v", err)
+	}
+
+	if !fractionEquals(a, NewScalarFrac(4660046610375530309)) || !fractionEquals(p.Get(2, 2), NewScalarFrac(7540113804746346429)) {
+		t.Errorf("Term 91 of the Fibonacci sequence should be 4660046610375530309 but was %v, with matrix power\n%v", a, p)
+	}
+
+	if _, err := NewRecurrence(fracs(1, 0), fracs(0, 1)); err == nil {
+		t.Error("A recurrence whose last coefficient is zero should be rejected")
+	}
+
+	if _, err := NewRecurrence(fracs(1, 1), fracs(0)); err == nil {
+		t.Error("A recurrence of order 2 with one initial value should be rejected")
+	}
+}
+
+func TestRecurrenceClosedForm(t *testing.T) {
+	tests := []struct {
+		coeffs, initial []Frac
+		expected        string
+	}{
+		{fracs(1, 1), fracs(0, 1), "a_n = ((1/5)√5)·(1/2 + (1/2)√5)^n + ((-1/5)√5)·(1/2 - (1/2)√5)^n"},
+		{fracs(1, 2), fracs(0, 1), "a_n = -(1/3)·(-1)^n + (1/3)·2^n"},
+		{fracs(4, -4), fracs(1, 4), "a_n = 2^n + n·2^n"},
+		{fracs(1), fracs(7), "a_n = 7"},
+	}
+
+	for _, test := range tests {
+		rec, err := NewRecurrence(test.coeffs, test.initial)
+		if err != nil {
+			t.Fatalf("Got error while building the recurrence %v: %v", test.coeffs, err)
+		}
+
+		s, err := rec.ClosedForm()
+		if err != nil {
+			t.Fatalf("Got error while finding the closed form of %v: %v", test.coeffs, err)
+		}
+
+		if s != test.expected {
+			t.Errorf("The closed form of %v with initial values %v should be\n %s but was\n %s", test.coeffs, test.initial, test.expected, s)
+		}
+	}
+
+	rec, _ := NewRecurrence(fracs(0, -1), fracs(1, 0))
+	if _, err := rec.ClosedForm(); err == nil {
+		t.Error("a_{n+2} = -a_n has complex roots, so it should have no closed form here")
+	}
+}
@@ -0,0 +1,257 @@
+package matrix
+
+import (
+	"fmt"
+	"strconv"
+)
+
+//LPStatus describes the outcome of a linear program.
+type LPStatus int
+
+//LPStatus definitions
+const (
+	Optimal    LPStatus = iota // an optimal solution was found
+	Infeasible                 // no point satisfies the constraints
+	Unbounded                  // the objective grows without bound over the feasible region
+)
+
+func (s LPStatus) String() string {
+	switch s {
+	case Optimal:
+		return "optimal"
+	case Infeasible:
+		return "infeasible"
+	case Unbounded:
+		return "unbounded"
+	}
+
+	return "unknown"
+}
+
+//LPStep is one tableau of the simplex method. The objective row is last and the right-hand side is the last column.
+//Entering and Leaving are the indices into LPSolution.Vars of the variables swapped by the pivot which produced the tableau,
+//or -1 for the first tableau of a phase.
+type LPStep struct {
+	Phase             int
+	Tableau           M
+	Entering, Leaving int
+}
+
+//LPSolution is the result of the simplex method.
+type LPSolution struct {
+	Status LPStatus
+
+	X     M    //the optimal point as a column vector, if Status is Optimal
+	Value Frac //the optimal value of the objective, if Status is Optimal
+
+	Vars  []string //the names of the tableau columns: decision variables x, slacks s and artificials a, which phase two drops
+	Steps []LPStep
+}
+
+//Simplex maximizes c·x subject to ax <= b and x >= 0 using the two-phase simplex method in exact arithmetic.
+//Constraints with a negative right-hand side are turned around and given an artificial variable, which the first phase drives to zero.
+//Bland's rule picks the entering and leaving variables, so the method cannot cycle.
+//An error is returned if the sizes of c, a and b do not match.
+func Simplex(c []Frac, a M, b []Frac) (LPSolution, error) {
+	m, n := a.Rows(), a.Cols()
+
+	if len(c) != n {
+		return LPSolution{}, fmt.Errorf("the objective has %d coefficients, but the constraints have %d variables", len(c), n)
+	}
+
+	if len(b) != m {
+		return LPSolution{}, fmt.Errorf("the right-hand side has %d entries, but there are %d constraints", len(b), m)
+	}
+
+	//Rows with a negative right-hand side are negated and need an artificial variable.
+	flipped := make([]bool, m)
+	k := 0
+	for i := range b {
+		if b[i].Numerator() < 0 {
+			flipped[i] = true
+			k++
+		}
+	}
+
+	sol := LPSolution{}
+	for j := 1; j <= n; j++ {
+		sol.Vars = append(sol.Vars, "x"+strconv.Itoa(j))
+	}
+	for i := 1; i <= m; i++ {
+		sol.Vars = append(sol.Vars, "s"+strconv.Itoa(i))
+	}
+	for i := 1; i <= k; i++ {
+		sol.Vars = append(sol.Vars, "a"+strconv.Itoa(i))
+	}
+
+	cols := n + m + k
+	t := New(m+1, cols+1)
+	basis := make([]int, m)
+
+	art := n + m
+	for i := 0; i < m; i++ {
+		sign := NewScalarFrac(1)
+		if flipped[i] {
+			sign = NewScalarFrac(-1)
+		}
+
+		for j := 0; j < n; j++ {
+			t.Set(i+1, j+1, a.Get(i+1, j+1).Mul(sign))
+		}
+		t.Set(i+1, n+i+1, sign)
+		t.Set(i+1, cols+1, b[i].Mul(sign))
+
+		basis[i] = n + i
+		if flipped[i] {
+			t.Set(i+1, art+1, NewScalarFrac(1))
+			basis[i] = art
+			art++
+		}
+	}
+
+	if k > 0 {
+		//Phase one maximizes the negated sum of the artificial variables, written in terms of the starting basis.
+		for j := n + m; j < cols; j++ {
+			t.Set(m+1, j+1, NewScalarFrac(1))
+		}
+		for i := 0; i < m; i++ {
+			if flipped[i] {
+				t.MultiplyAndAddRow(i+1, NewScalarFrac(-1), m+1)
+			}
+		}
+
+		sol.Steps = append(sol.Steps, LPStep{Phase: 1, Tableau: CopyMatrix(t), Entering: -1, Leaving: -1})
+
+		if !runSimplex(&t, basis, cols, 1, &sol) {
+			return sol, nil // phase one is bounded above by zero, so this cannot happen
+		}
+
+		if !t.Get(m+1, cols+1).IsZero() {
+			sol.Status = Infeasible
+			return sol, nil
+		}
+
+		//Pivot any artificial variable left in the basis out of it; its row is redundant if nothing else can replace it.
+		keep := []int{}
+		for i := 0; i < m; i++ {
+			if basis[i] < n+m {
+				keep = append(keep, i)
+				continue
+			}
+
+			for j := 0; j < n+m; j++ {
+				if !t.Get(i+1, j+1).IsZero() {
+					pivot(&t, i, j)
+					sol.Steps = append(sol.Steps, LPStep{Phase: 1, Tableau: CopyMatrix(t), Entering: j, Leaving: basis[i]})
+					basis[i] = j
+					keep = append(keep, i)
+					break
+				}
+			}
+		}
+
+		//Drop the artificial columns and the redundant rows.
+		reduced := New(len(keep)+1, n+m+1)
+		newBasis := make([]int, len(keep))
+		for r, i := range keep {
+			for j := 0; j < n+m; j++ {
+				reduced.Set(r+1, j+1, t.Get(i+1, j+1))
+			}
+			reduced.Set(r+1, n+m+1, t.Get(i+1, cols+1))
+			newBasis[r] = basis[i]
+		}
+
+		t, basis, cols, m = reduced, newBasis, n+m, len(keep)
+	}
+
+	//Phase two maximizes the real objective, starting from the feasible basis.
+	for j := 0; j < cols+1; j++ {
+		t.Set(m+1, j+1, NewScalarFrac(0))
+	}
+	for j := 0; j < n; j++ {
+		t.Set(m+1, j+1, c[j].Neg())
+	}
+	for i := 0; i < m; i++ {
+		if s := t.Get(m+1, basis[i]+1); !s.IsZero() {
+			t.MultiplyAndAddRow(i+1, s.Neg(), m+1)
+		}
+	}
+
+	sol.Steps = append(sol.Steps, LPStep{Phase: 2, Tableau: CopyMatrix(t), Entering: -1, Leaving: -1})
+
+	if !runSimplex(&t, basis, cols, 2, &sol) {
+		sol.Status = Unbounded
+		return sol, nil
+	}
+
+	sol.Status = Optimal
+	sol.Value = t.Get(m+1, cols+1).Reduce()
+	sol.X = New(n, 1)
+	for i := 0; i < m; i++ {
+		if basis[i] < n {
+			sol.X.Set(basis[i]+1, 1, t.Get(i+1, cols+1).Reduce())
+		}
+	}
+
+	return sol, nil
+}
+
+//runSimplex pivots the tableau until its objective row has no negative entry among the first cols columns, recording each tableau.
+//Bland's rule is used: the entering variable is the lowest-numbered improving one, and ties between leaving variables are
+//broken the same way. It returns false if the objective is unbounded.
+func runSimplex(t *M, basis []int, cols, phase int, sol *LPSolution) bool {
+	m := len(basis)
+
+	for {
+		enter := -1
+		for j := 0; j < cols; j++ {
+			if t.Get(m+1, j+1).Numerator() < 0 {
+				enter = j
+				break
+			}
+		}
+
+		if enter == -1 {
+			return true
+		}
+
+		leave := -1
+		var best Frac
+		for i := 0; i < m; i++ {
+			e := t.Get(i+1, enter+1)
+			if e.Numerator() <= 0 {
+				continue
+			}
+
+			ratio := t.Get(i+1, t.Cols()).Div(e)
+			if leave == -1 {
+				leave, best = i, ratio
+				continue
+			}
+
+			diff := ratio.Add(best.Neg())
+			if diff.Numerator() < 0 || diff.IsZero() && basis[i] < basis[leave] {
+				leave, best = i, ratio
+			}
+		}
+
+		if leave == -1 {
+			return false
+		}
+
+		pivot(t, leave, enter)
+		sol.Steps = append(sol.Steps, LPStep{Phase: phase, Tableau: CopyMatrix(*t), Entering: enter, Leaving: basis[leave]})
+		basis[leave] = enter
+	}
+}
+
+//pivot scales row r of the tableau so that column c holds a one there, then clears the rest of column c.
+func pivot(t *M, r, c int) {
+	t.MultiplyRow(r+1, t.Get(r+1, c+1).Inv())
+
+	for i := 1; i <= t.Rows(); i++ {
+		if i != r+1 && !t.Get(i, c+1).IsZero() {
+			t.MultiplyAndAddRow(r+1, t.Get(i, c+1).Neg(), i)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package matrix
+
+import "testing"
+
+func TestSimplex(t *testing.T) {
+	//maximize 3x1 + 5x2 subject to x1 <= 4, 2x2 <= 12, 3x1 + 2x2 <= 18
+	a := manualMatrix([][]string{
+		{"1", "0"},
+		{"0", "2"},
+		{"3", "2"},
+	})
+
+	sol, err := Simplex(fracs(3, 5), a, fracs(4, 12, 18))
+	if err != nil {
+		t.Fatalf("Got error during simplex: %v", err)
+	}
+
+	expected := manualMatrix([][]string{{"2"}, {"6"}})
+
+	if sol.Status != Optimal || !matrixEquals(sol.X, expected) || !sol.Value.Equals(NewScalarFrac(36)) {
+		t.Errorf("Wanted optimum 36 at\n %v but got %v with value %v at\n %v", expected, sol.Status, sol.Value, sol.X)
+	}
+}
+
+func TestSimplexTwoPhase(t *testing.T) {
+	//maximize -x1 - x2 subject to x1 + x2 >= 2, x1 <= 3, written as -x1 - x2 <= -2
+	a := manualMatrix([][]string{
+		{"-1", "-1"},
+		{"1", "0"},
+	})
+
+	sol, err := Simplex(fracs(-1, -1), a, fracs(-2, 3))
+	if err != nil {
+		t.Fatalf("Got error during simplex: %v", err)
+	}
+
+	if sol.Status != Optimal || !sol.Value.Equals(NewScalarFrac(-2)) {
+		t.Errorf("Wanted optimum -2 but got %v with value %v", sol.Status, sol.Value)
+	}
+
+	if sol.Steps[0].Phase != 1 {
+		t.Error("A negative right-hand side must start with phase one!")
+	}
+
+	//x1 <= 1 and x1 >= 2 cannot both hold
+	infeasible := manualMatrix([][]string{
+		{"1"},
+		{"-1"},
+	})
+
+	if sol, _ := Simplex(fracs(1), infeasible, fracs(1, -2)); sol.Status != Infeasible {
+		t.Errorf("Wanted an infeasible problem but got %v", sol.Status)
+	}
+
+	//x1 - x2 <= 1 leaves x2 free to grow
+	unbounded := manualMatrix([][]string{
+		{"1", "-1"},
+	})
+
+	if sol, _ := Simplex(fracs(0, 1), unbounded, fracs(1)); sol.Status != Unbounded {
+		t.Errorf("Wanted an unbounded problem but got %v", sol.Status)
+	}
+}
+
+func TestSimplexDegenerate(t *testing.T) {
+	//Beale's example, which cycles under the textbook largest-coefficient rule.
+	a := manualMatrix([][]string{
+		{"1/4", "-60", "-1/25", "9"},
+		{"1/2", "-90", "-1/50", "3"},
+		{"0", "0", "1", "0"},
+	})
+
+	c := []Frac{NewFrac(3, 4), NewScalarFrac(-150), NewFrac(1, 50), NewScalarFrac(-6)}
+
+	sol, err := Simplex(c, a, fracs(0, 0, 1))
+	if err != nil {
+		t.Fatalf("Got error during simplex: %v", err)
+	}
+
+	if sol.Status != Optimal || !sol.Value.Equals(NewFrac(1, 20)) {
+		t.Errorf("Wanted optimum 1/20 but got %v with value %v", sol.Status, sol.Value)
+	}
+}